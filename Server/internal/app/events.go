@@ -13,6 +13,7 @@ const (
 	EventPigChopped   EventKind = "pig_chopped"
 	EventTurnPassed   EventKind = "turn_passed"
 	EventPlayerFinished EventKind = "player_finished"
+	EventInstantWin   EventKind = "instant_win"
 	EventGameEnded    EventKind = "game_ended"
 )
 
@@ -34,9 +35,10 @@ type PlayerLeftPayload struct {
 }
 
 type GameStartedPayload struct {
-	Phase         domain.Phase
-	FirstTurnSeat int
-	Hand          []domain.Card
+	Phase          domain.Phase
+	FirstTurnSeat  int
+	Hand           []domain.Card
+	SeedCommitment string // SHA-256 of the server seed; empty when the deck was supplied directly
 }
 
 type PigChoppedPayload struct {
@@ -46,11 +48,19 @@ type PigChoppedPayload struct {
 	CardsChopped   []domain.Card
 	CardsChopping  []domain.Card
 	BalanceChanges map[string]int64
+	Chain          []domain.ChopLink // Full chop chain this round, victim first
 }
 
 type PlayerFinishedPayload struct {
-	Seat int
-	Rank int
+	Seat   int
+	Rank   int
+	Frozen bool // "Cong": never played a card and was ranked last
+}
+
+type InstantWinPayload struct {
+	Seat    int
+	WinType string // e.g. "Dragon Straight", "Four Twos"
+	Cards   []domain.Card
 }
 
 type CardPlayedPayload struct {
@@ -77,4 +87,13 @@ type GameEndedPayload struct {
 	BalanceChanges map[string]int64 // UserID -> Gold (+/-)
 
 	RemainingHands map[int][]domain.Card // Seat -> Cards
+
+	Penalties []domain.PenaltyItem // Leftover 2s/bombs and cong charges, already in BalanceChanges
+
+	FrozenSeats []int // Seats flagged "cong"
+
+	// Provably fair deal reveal; empty when the deck was supplied directly (see domain.VerifyDeal).
+	SeedCommitment string
+	ServerSeed     string // Hex
+	ClientSalt     string
 }
@@ -1,10 +1,11 @@
 package app
 
 import (
+	crand "crypto/rand"
 	"errors"
+	"fmt"
 	"math/rand"
 	"sort"
-	"time"
 
 	"tienlen/internal/config"
 	"tienlen/internal/domain"
@@ -12,67 +13,133 @@ import (
 
 // Service contains Tien Len use-cases operating on domain state.
 type Service struct {
-	rng *rand.Rand
+	rng           *rand.Rand
+	rules         domain.RuleSet
+	chopPenalties domain.ChopPenaltyTable
+	settlement    domain.SettlementPolicy
+	debugChecks   bool
 }
 
-// NewService constructs a Service with provided rng or a time-seeded default.
-func NewService(rng *rand.Rand) *Service {
-	if rng == nil {
-		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+// Option customizes a Service at construction time.
+type Option func(*Service)
+
+// WithRuleSet selects the regional rules applied to every game started by the Service.
+func WithRuleSet(rules domain.RuleSet) Option {
+	return func(s *Service) {
+		s.rules = rules
 	}
-	return &Service{rng: rng}
 }
 
-var (
-	ErrNotOwner         = errors.New("actor is not match owner")
-	ErrNotPlaying       = errors.New("match not in playing phase")
-	ErrTooFewPlayers    = errors.New("not enough players to start")
-	ErrUnknownPlayer    = errors.New("player not found")
-	ErrPlayerFinished   = errors.New("player already finished")
-	ErrNotYourTurn      = errors.New("not your turn")
-	ErrInvalidPlay      = errors.New("invalid card play")
-	ErrCardsNotInHand   = errors.New("cards not in hand")
-	ErrCannotBeat       = errors.New("cannot beat previous play")
-	ErrGameNotEnded     = errors.New("game not ended")
-	ErrGameAlreadyEnded = errors.New("game already ended")
-)
+// WithChopPenalties sets the chop price table applied to every game started by the Service.
+func WithChopPenalties(table domain.ChopPenaltyTable) Option {
+	return func(s *Service) {
+		s.chopPenalties = table
+	}
+}
 
-// StartGame initializes a new Game domain object with the provided players.
-// It expects a list of userIDs representing the players in seat order (empty strings for empty seats).
-func (s *Service) StartGame(playerIDs []string, lastWinnerSeat int, baseBet int64) (*domain.Game, []Event, error) {
-	activePlayers := make(map[string]*domain.Player)
-	var seats []string // Active players' UIDs in seat order
+// WithSettlementPolicy sets how games started by the Service are paid out and taxed.
+func WithSettlementPolicy(policy domain.SettlementPolicy) Option {
+	return func(s *Service) {
+		s.settlement = policy
+	}
+}
 
-	// Filter empty seats and create players
-	for i, userID := range playerIDs {
-		if userID == "" {
-			continue
-		}
-		activePlayers[userID] = &domain.Player{
-			UserID: userID,
-			Seat:   i, // 0-based seat index for domain
-		}
-		seats = append(seats, userID)
+// WithInvariantChecks makes the Service validate the game after every mutation (see domain.Game.Validate)
+// and fail the action with the violation. Meant for debug builds; it costs a full scan per action.
+func WithInvariantChecks(enabled bool) Option {
+	return func(s *Service) {
+		s.debugChecks = enabled
 	}
+}
 
-	minPlayers := 2
-	if cfg := config.GetGameConfig(); cfg != nil {
-		minPlayers = cfg.MinPlayersToStartGame
+// NewService constructs a Service. Server seeds for deals are drawn from rng when it is given,
+// making deals reproducible, and from crypto/rand when it is nil.
+// Games use Southern rules unless another rule set is given via WithRuleSet.
+func NewService(rng *rand.Rand, opts ...Option) *Service {
+	s := &Service{rng: rng}
+	for _, opt := range opts {
+		opt(s)
 	}
+	if s.rules == nil {
+		s.rules = domain.SouthernRules{}
+	}
+	if s.chopPenalties == (domain.ChopPenaltyTable{}) {
+		s.chopPenalties = domain.DefaultChopPenaltyTable()
+	}
+	if s.settlement == nil {
+		// Standard rank matrix with the configured tax rate.
+		taxRate := defaultTaxRate
+		if cfg := config.GetGameConfig(); cfg != nil {
+			taxRate = cfg.TaxRate
+		}
+		s.settlement = domain.RankMatrixPolicy{Matrix: domain.DefaultRankMatrix(), Tax: domain.Tax{Rate: taxRate}}
+	}
+	return s
+}
 
-	if len(activePlayers) < minPlayers {
-		return nil, nil, ErrTooFewPlayers
+// NewServerSeed draws a secret server seed for a deal (see domain.DealSeed).
+func (s *Service) NewServerSeed() ([]byte, error) {
+	seed := make([]byte, domain.ServerSeedSize)
+	if s.rng != nil {
+		s.rng.Read(seed)
+		return seed, nil
+	}
+	if _, err := crand.Read(seed); err != nil {
+		return nil, fmt.Errorf("failed to draw server seed: %w", err)
 	}
+	return seed, nil
+}
+
+// RuleSet returns the rules applied to games started by the Service.
+func (s *Service) RuleSet() domain.RuleSet {
+	return s.rules
+}
+
+var (
+	ErrNotOwner            = errors.New("actor is not match owner")
+	ErrNotPlaying          = errors.New("match not in playing phase")
+	ErrTooFewPlayers       = errors.New("not enough players to start")
+	ErrUnknownPlayer       = errors.New("player not found")
+	ErrPlayerFinished      = errors.New("player already finished")
+	ErrNotYourTurn         = errors.New("not your turn")
+	ErrInvalidPlay         = errors.New("invalid card play")
+	ErrCardsNotInHand      = errors.New("cards not in hand")
+	ErrCannotBeat          = errors.New("cannot beat previous play")
+	ErrGameNotEnded        = errors.New("game not ended")
+	ErrGameAlreadyEnded    = errors.New("game already ended")
+	ErrOpeningCardRequired = errors.New("first play must include the opening card")
+)
 
-	deck := domain.NewDeck()
-	deck = domain.ShuffleDeck(deck)
+const (
+	defaultInstantWinMultiplier = 3
+	defaultCongMultiplier       = 3
+	defaultTaxRate              = 0.05
+)
 
-	return s.StartGameWithDeck(playerIDs, lastWinnerSeat, baseBet, deck)
+// StartGame initializes a new Game domain object with the provided players.
+// It expects a list of userIDs representing the players in seat order (empty strings for empty seats).
+func (s *Service) StartGame(playerIDs []string, lastWinnerSeat int, baseBet int64) (*domain.Game, []Event, error) {
+	serverSeed, err := s.NewServerSeed()
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.StartGameWithSeed(playerIDs, lastWinnerSeat, baseBet, domain.DealSeed{ServerSeed: serverSeed})
+}
+
+// StartGameWithSeed initializes a game dealt from a provably fair shuffle.
+// GameStarted events carry the seed's commitment and the GameEnded event reveals the seed.
+func (s *Service) StartGameWithSeed(playerIDs []string, lastWinnerSeat int, baseBet int64, seed domain.DealSeed) (*domain.Game, []Event, error) {
+	return s.startGame(playerIDs, lastWinnerSeat, baseBet, seed.Shuffle(domain.NewDeck()), &seed)
 }
 
 // StartGameWithDeck initializes a game with a specific deck order.
 // Useful for testing or deterministic game modes.
 func (s *Service) StartGameWithDeck(playerIDs []string, lastWinnerSeat int, baseBet int64, deck []domain.Card) (*domain.Game, []Event, error) {
+	return s.startGame(playerIDs, lastWinnerSeat, baseBet, deck, nil)
+}
+
+// startGame deals deck to the seated players; seed is nil when the deck was supplied directly.
+func (s *Service) startGame(playerIDs []string, lastWinnerSeat int, baseBet int64, deck []domain.Card, seed *domain.DealSeed) (*domain.Game, []Event, error) {
 	activePlayers := make(map[string]*domain.Player)
 	var seats []string // Active players' UIDs in seat order
 
@@ -102,6 +169,10 @@ func (s *Service) StartGameWithDeck(playerIDs []string, lastWinnerSeat int, base
 		Players:              activePlayers,
 		LastPlayerToPlaySeat: -1, // Initialize to -1 as no one has played yet
 		BaseBet:              baseBet,
+		Rules:                s.rules,
+		ChopPenalties:        s.chopPenalties,
+		Policy:               s.settlement,
+		Seed:                 seed,
 	}
 
 	// Deal cards
@@ -152,7 +223,42 @@ func (s *Service) StartGameWithDeck(playerIDs []string, lastWinnerSeat int, base
 	}
 	game.CurrentTurn = firstTurnSeat
 
-	events := make([]Event, 0, len(activePlayers))
+	// A fresh table opens with the lowest card dealt (the 3 of Spades at a full table).
+	if lastWinnerSeat < 0 && s.rules.Options().OpeningCard {
+		if card, ok := game.FindOpeningCard(); ok {
+			game.OpeningCard = &card
+		}
+	}
+
+	game.InstantWinMultiplier = defaultInstantWinMultiplier
+	if cfg := config.GetGameConfig(); cfg != nil && cfg.InstantWinMultiplier > 0 {
+		game.InstantWinMultiplier = cfg.InstantWinMultiplier
+	}
+
+	game.CongMultiplier = defaultCongMultiplier
+	if cfg := config.GetGameConfig(); cfg != nil && cfg.CongMultiplier > 0 {
+		game.CongMultiplier = cfg.CongMultiplier
+	}
+
+	game.LeftoverPenalties = domain.DefaultLeftoverPenaltyRates()
+	if cfg := config.GetGameConfig(); cfg != nil && cfg.LeftoverPenalties != nil {
+		lp := cfg.LeftoverPenalties
+		game.LeftoverPenalties = domain.LeftoverPenaltyRates{
+			BlackPig:  lp.BlackPig,
+			RedPig:    lp.RedPig,
+			Quad:      lp.Quad,
+			ThreePine: lp.ThreePine,
+			FourPine:  lp.FourPine,
+			FivePine:  lp.FivePine,
+		}
+	}
+
+	events := make([]Event, 0, len(activePlayers)+2)
+
+	var commitment string
+	if seed != nil {
+		commitment = seed.Commitment()
+	}
 
 	// Create GameStarted event for EACH player, containing their private hand
 	for _, userID := range seats {
@@ -160,17 +266,111 @@ func (s *Service) StartGameWithDeck(playerIDs []string, lastWinnerSeat int, base
 		events = append(events, Event{
 			Kind: EventGameStarted,
 			Payload: GameStartedPayload{
-				Phase:         game.Phase,
-				FirstTurnSeat: game.CurrentTurn,
-				Hand:          pl.Hand,
+				Phase:          game.Phase,
+				FirstTurnSeat:  game.CurrentTurn,
+				Hand:           pl.Hand,
+				SeedCommitment: commitment,
 			},
 			Recipients: []string{pl.UserID},
 		})
 	}
 
+	// An instant win (toi trang) ends the game before anyone plays.
+	if winner, winType := s.findInstantWinner(game); winner != nil {
+		game.Phase = domain.PhaseEnded
+		game.InstantWin = winType
+		game.FinishOrderSeats = []int{winner.Seat}
+		for _, userID := range seats {
+			if pl := activePlayers[userID]; pl.Seat != winner.Seat {
+				game.FinishOrderSeats = append(game.FinishOrderSeats, pl.Seat)
+			}
+		}
+
+		events = append(events, Event{
+			Kind: EventInstantWin,
+			Payload: InstantWinPayload{
+				Seat:    winner.Seat,
+				WinType: winType,
+				Cards:   winner.Hand,
+			},
+		}, Event{
+			Kind:    EventGameEnded,
+			Payload: s.buildGameEndedPayload(game),
+		})
+	}
+
+	if err := s.checkInvariants(game, "start game"); err != nil {
+		return nil, nil, err
+	}
 	return game, events, nil
 }
 
+// checkInvariants validates the game when invariant checks are enabled.
+func (s *Service) checkInvariants(game *domain.Game, action string) error {
+	if !s.debugChecks {
+		return nil
+	}
+	if err := game.Validate(); err != nil {
+		return fmt.Errorf("after %s: %w", action, err)
+	}
+	return nil
+}
+
+// findInstantWinner returns the first player, in turn order from the current turn,
+// whose dealt hand is an instant win.
+func (s *Service) findInstantWinner(game *domain.Game) (*domain.Player, string) {
+	var orderedPlayers []*domain.Player
+	for _, pl := range game.Players {
+		orderedPlayers = append(orderedPlayers, pl)
+	}
+	sort.Slice(orderedPlayers, func(i, j int) bool {
+		return orderedPlayers[i].Seat < orderedPlayers[j].Seat
+	})
+
+	startIdx := 0
+	for i, pl := range orderedPlayers {
+		if pl.Seat == game.CurrentTurn {
+			startIdx = i
+			break
+		}
+	}
+
+	for i := 0; i < len(orderedPlayers); i++ {
+		pl := orderedPlayers[(startIdx+i)%len(orderedPlayers)]
+		if ok, winType := domain.DetectInstantWin(pl.Hand, game.RuleSet()); ok {
+			return pl, winType
+		}
+	}
+	return nil, ""
+}
+
+// buildGameEndedPayload settles an ended game, applies tax to winnings and collects remaining hands.
+func (s *Service) buildGameEndedPayload(game *domain.Game) GameEndedPayload {
+	// Tax is withheld by the game's settlement policy.
+	settlement := game.CalculateSettlement()
+
+	remainingHands := make(map[int][]domain.Card)
+	for _, p := range game.Players {
+		if len(p.Hand) > 0 {
+			remainingHands[p.Seat] = p.Hand
+		}
+	}
+
+	payload := GameEndedPayload{
+		FinishOrderSeats: game.FinishOrderSeats,
+		BalanceChanges:   settlement.BalanceChanges,
+		RemainingHands:   remainingHands,
+		Penalties:        settlement.Penalties,
+		FrozenSeats:      game.FrozenSeats(),
+	}
+	if game.Seed != nil {
+		payload.SeedCommitment = game.Seed.Commitment()
+		payload.ServerSeed = game.Seed.ServerSeedHex()
+		payload.ClientSalt = game.Seed.ClientSalt
+	}
+	return payload
+}
+
 // PlayCards processes a play action and emits resulting events.
 
 func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Card) ([]Event, error) {
@@ -222,9 +422,14 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 
 	}
 
+	if game.OpeningCard != nil && !playerHasCards(cards, []domain.Card{*game.OpeningCard}) {
+		return nil, ErrOpeningCardRequired
+	}
+
 	// 2. Identify the combination of played cards
 
-	playedCombo := domain.IdentifyCombination(cards)
+	rules := game.RuleSet()
+	playedCombo := rules.IdentifyCombination(cards)
 
 	if playedCombo.Type == domain.Invalid {
 
@@ -236,63 +441,26 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 
 	newRound := game.LastPlayedCombination.Type == domain.Invalid
 	var chopEvent *Event
+	if newRound {
+		game.ChopChain = nil
+	}
 
 	if !newRound { // If there was a previous play
 
-		if !domain.CanBeat(game.LastPlayedCombination.Cards, playedCombo.Cards) {
+		if !rules.CanBeat(game.LastPlayedCombination.Cards, playedCombo.Cards) {
 
 			return nil, ErrCannotBeat
 
 		}
 
 		// Check for Pig Chop
-		if isChop, chopType := domain.DetectChop(game.LastPlayedCombination.Cards, playedCombo.Cards); isChop {
+		if isChop, chopType := rules.DetectChop(game.LastPlayedCombination.Cards, playedCombo.Cards); isChop {
 			// Calculate Penalty based on the VICTIM (what was chopped)
-			multiplier := int64(1)
 			victimCombo := game.LastPlayedCombination
-			
-			// Identify Victim Type logic
-			// Note: domain doesn't export specific "IsRedPig" helpers easily, so we inspect cards manually.
-			// Ideally domain should export IdentifyCombinationType more granularly, but we can infer.
-			
-			switch victimCombo.Type {
-			case domain.Single:
-				// Must be a 2 if it was chopped. Check Suit.
-				// Suit: Spades(0), Clubs(1), Diamonds(2), Hearts(3)
-				if len(victimCombo.Cards) == 1 && victimCombo.Cards[0].Rank == 12 {
-					if victimCombo.Cards[0].Suit >= 2 { // Diamond or Heart
-						multiplier = 2 // Red Pig
-					} else {
-						multiplier = 1 // Black Pig
-					}
-				}
-			case domain.Pair:
-				// Pair of 2s
-				if len(victimCombo.Cards) == 2 && victimCombo.Cards[0].Rank == 12 {
-					// Check color of the pair.
-					// 2 Black = 2 units? 1 Black 1 Red = 3? 2 Red = 4?
-					// For simplicity: Base 2 units for Pair 2 (standard variation often used).
-					// Or sum of individual pigs. Let's use simpler: Pair 2 = 4 units (approx 2 red pigs).
-					// Let's stick to "Pair 2 = 4 Units" for this iteration or "Red Pair = 8, Black = 4".
-					// Simplest: Pair 2 = 4 Units.
-					multiplier = 4
-				}
-			case domain.Bomb:
-				// Could be Quad, 3-Pine, 4-Pine, 5-Pine
-				// We need to re-identify or check Count
-				switch victimCombo.Count {
-				case 4: 
-					multiplier = 4 // Quad
-				case 6:
-					multiplier = 3 // 3-Pine (usually less than Quad in value for chopping purposes? Varies. Let's say 3)
-				case 8:
-					multiplier = 5 // 4-Pine
-				case 10:
-					multiplier = 10 // 5-Pine
-				}
-			}
+			multiplier := game.ChopMultiplier(victimCombo)
 
-			amount := game.BaseBet * multiplier
+			// Stacked chops: the victim also hands over everything they won earlier in the chain.
+			amount := game.RecordChop(actorSeat, playedCombo.Cards, chopType, game.BaseBet*multiplier)
 			
 			// Identify Source (Current Actor) and Target (Last Player)
 			targetSeat := game.LastPlayerToPlaySeat
@@ -320,6 +488,7 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 							sourceID: amount,
 							targetID: -amount,
 						},
+						Chain: append([]domain.ChopLink(nil), game.ChopChain...),
 					},
 				}
 			}
@@ -330,6 +499,8 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 	// If valid, update game state
 
 	pl.Hand = domain.RemoveCards(pl.Hand, cards)
+	pl.HasPlayed = true
+	game.OpeningCard = nil
 
 	game.LastPlayedCombination = playedCombo
 
@@ -369,6 +540,21 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 			},
 		})
 
+		// Players who never played a card before the first finisher are frozen ("cong") and ranked last.
+		if len(game.FinishOrderSeats) == 1 {
+			frozen := game.FreezeUnplayedPlayers()
+			for i, fp := range frozen {
+				events = append(events, Event{
+					Kind: EventPlayerFinished,
+					Payload: PlayerFinishedPayload{
+						Seat:   fp.Seat,
+						Rank:   len(game.Players) - len(frozen) + i + 1,
+						Frozen: true,
+					},
+				})
+			}
+		}
+
 	}
 
 	// Check if game ended
@@ -378,71 +564,13 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 		game.Phase = domain.PhaseEnded
 
 		// Add the last remaining player(s) who haven't finished to the finish order.
-		// In standard Tien Len, there's exactly one such player when the game ends.
-		for _, p := range game.Players {
-			alreadyFinished := false
-			for _, finishedSeat := range game.FinishOrderSeats {
-				if finishedSeat == p.Seat {
-					alreadyFinished = true
-					break
-				}
-			}
-			if !alreadyFinished {
-				game.FinishOrderSeats = append(game.FinishOrderSeats, p.Seat)
-			}
-		}
-
-		settlement := game.CalculateSettlement()
-
-		// Apply Tax to positive winnings
-
-		cfg := config.GetGameConfig()
-
-		taxRate := 0.05 // Default
-
-		if cfg != nil {
-
-			taxRate = cfg.TaxRate
-
-		}
-
-		finalChanges := make(map[string]int64)
-
-		for uid, amount := range settlement.BalanceChanges {
-
-			if amount > 0 {
-
-				afterTax := float64(amount) * (1.0 - taxRate)
-
-				finalChanges[uid] = int64(afterTax)
-
-			} else {
-
-				finalChanges[uid] = amount
-
-			}
-
-		}
-
-		remainingHands := make(map[int][]domain.Card)
-		for _, p := range game.Players {
-			if len(p.Hand) > 0 {
-				remainingHands[p.Seat] = p.Hand
-			}
-		}
+		// In standard Tien Len, there's exactly one such player when the game ends,
+		// followed by any frozen players.
+		game.CompleteFinishOrder()
 
 		events = append(events, Event{
-
-			Kind: EventGameEnded,
-
-			Payload: GameEndedPayload{
-
-				FinishOrderSeats: game.FinishOrderSeats,
-
-				BalanceChanges: finalChanges,
-
-				RemainingHands: remainingHands,
-			},
+			Kind:    EventGameEnded,
+			Payload: s.buildGameEndedPayload(game),
 		})
 
 	} else {
@@ -468,6 +596,9 @@ func (s *Service) PlayCards(game *domain.Game, actorSeat int, cards []domain.Car
 
 	}
 
+	if err := s.checkInvariants(game, fmt.Sprintf("play by seat %d", actorSeat)); err != nil {
+		return nil, err
+	}
 	return events, nil
 
 }
@@ -498,7 +629,25 @@ func (s *Service) PassTurn(game *domain.Game, actorSeat int) ([]Event, error) {
 	}
 
 	pl.HasPassed = true
+	newRound := s.advanceTurn(game, actorSeat)
 
+	if err := s.checkInvariants(game, fmt.Sprintf("pass by seat %d", actorSeat)); err != nil {
+		return nil, err
+	}
+	return []Event{
+		{
+			Kind: EventTurnPassed,
+			Payload: TurnPassedPayload{
+				Seat:         actorSeat,
+				NextTurnSeat: game.CurrentTurn,
+				NewRound:     newRound,
+			},
+		},
+	}, nil
+}
+
+// advanceTurn moves the turn on from actorSeat after they passed or left, and reports whether a new round began.
+func (s *Service) advanceTurn(game *domain.Game, actorSeat int) bool {
 	// Check if the round should reset.
 	// The round resets if only one active (non-finished) player remains who hasn't passed.
 	// This player is the "winner" of the round and starts the new one.
@@ -536,6 +685,7 @@ func (s *Service) PassTurn(game *domain.Game, actorSeat int) ([]Event, error) {
 	if newRound {
 		// Reset LastPlayedCombination for new round
 		game.LastPlayedCombination = domain.CardCombination{Type: domain.Invalid}
+		game.ChopChain = nil
 		// Reset pass status for all active players
 		for _, p := range game.Players {
 			if !p.Finished {
@@ -543,17 +693,55 @@ func (s *Service) PassTurn(game *domain.Game, actorSeat int) ([]Event, error) {
 			}
 		}
 	}
+	return newRound
+}
 
-	return []Event{
-		{
+// LeaveGame forfeits the player in actorSeat, who left the match mid-game (see domain.Game.Forfeit).
+// If it was their turn, play moves on as if they had passed. Once fewer than two players hold cards
+// the game ends and everyone, the leaver included, is settled.
+func (s *Service) LeaveGame(game *domain.Game, actorSeat int) ([]Event, error) {
+	if game.Phase != domain.PhasePlaying {
+		return nil, ErrNotPlaying
+	}
+
+	var pl *domain.Player
+	for _, p := range game.Players {
+		if p.Seat == actorSeat {
+			pl = p
+			break
+		}
+	}
+	if pl == nil {
+		return nil, ErrUnknownPlayer
+	}
+	if !game.Forfeit(actorSeat) {
+		return nil, ErrPlayerFinished
+	}
+
+	var events []Event
+	if domain.CountPlayersWithCards(game) <= 1 {
+		game.Phase = domain.PhaseEnded
+		game.CompleteFinishOrder()
+		events = append(events, Event{
+			Kind:    EventGameEnded,
+			Payload: s.buildGameEndedPayload(game),
+		})
+	} else if game.CurrentTurn == actorSeat {
+		newRound := s.advanceTurn(game, actorSeat)
+		events = append(events, Event{
 			Kind: EventTurnPassed,
 			Payload: TurnPassedPayload{
 				Seat:         actorSeat,
 				NextTurnSeat: game.CurrentTurn,
 				NewRound:     newRound,
 			},
-		},
-	}, nil
+		})
+	}
+
+	if err := s.checkInvariants(game, fmt.Sprintf("leave by seat %d", actorSeat)); err != nil {
+		return nil, err
+	}
+	return events, nil
 }
 
 // findNextActivePlayerInOrder finds the next active player seat-wise after the given seat.
@@ -591,19 +779,10 @@ func (s *Service) findNextActivePlayerInOrder(game *domain.Game, currentSeat int
 	}
 	
 	// playerHasCards checks if a player's hand contains all cards in 'toCheck'.
+	// Playing the same card twice is never allowed, so duplicates in 'toCheck' fail the check.
 	func playerHasCards(hand []domain.Card, toCheck []domain.Card) bool {
-		handCounts := make(map[domain.Card]int)
-		for _, card := range hand {
-			handCounts[card]++
-		}
-	
-		for _, card := range toCheck {
-			if count, ok := handCounts[card]; !ok || count == 0 {
-				return false // Player does not have this card or not enough of it
-			}
-			handCounts[card]--
-		}
-		return true
+		want := domain.NewCardSet(toCheck)
+		return want.Len() == len(toCheck) && domain.NewCardSet(hand).ContainsAll(want)
 	}
 	
 	// findNextPlayer determines whose turn it is next.
@@ -655,24 +834,15 @@ func (s *Service) findNextActivePlayerInOrder(game *domain.Game, currentSeat int
 		isNewRound := game.LastPlayedCombination.Type == domain.Invalid
 	
 		if isNewRound {
-			// Must play a card (cannot pass on new round)
-			// Find player's hand
-			var player *domain.Player
-			for _, p := range game.Players {
-				if p.Seat == actorSeat {
-					player = p
-					break
-				}
+			// Must play a card (cannot pass on new round): force the weakest legal single,
+			// which is the opening card on a fresh table
+			singles := game.LegalMoves(actorSeat).Of(domain.Single)
+			if len(singles) == 0 {
+				return nil, ErrUnknownPlayer
 			}
-		if player == nil || len(player.Hand) == 0 {
-			return nil, ErrUnknownPlayer
+			return s.PlayCards(game, actorSeat, singles[0].Cards)
 		}
-
-		// Force play the smallest single card
-		cardToPlay := domain.GetSmallestCard(player.Hand)
-		return s.PlayCards(game, actorSeat, []domain.Card{cardToPlay})
-	}
-
+	
 	// 2. Mid-round: Force Pass
 	return s.PassTurn(game, actorSeat)
 }
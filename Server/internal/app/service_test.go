@@ -8,6 +8,34 @@ import (
 	"tienlen/internal/domain"
 )
 
+// testDeck returns a fixed shuffled deck in which no seat is dealt an instant win,
+// so tests that rig hands after dealing always start in the playing phase.
+func testDeck() []domain.Card {
+	rng := rand.New(rand.NewSource(1))
+	for {
+		deck := domain.NewDeck()
+		rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+
+		instantWin := false
+		for seat := 0; seat < 4; seat++ {
+			if ok, _ := domain.DetectInstantWin(deck[seat*13:seat*13+13], domain.SouthernRules{}); ok {
+				instantWin = true
+				break
+			}
+		}
+		if !instantWin {
+			return deck
+		}
+	}
+}
+
+// markAllPlayed simulates a game already in progress, so nobody is frozen ("cong") when a player finishes.
+func markAllPlayed(game *domain.Game) {
+	for _, p := range game.Players {
+		p.HasPlayed = true
+	}
+}
+
 func TestStartGameDealsHands(t *testing.T) {
 	rng := rand.New(rand.NewSource(42))
 	svc := NewService(rng)
@@ -58,7 +86,7 @@ func TestPlayCardsAndEnd(t *testing.T) {
 	rng := rand.New(rand.NewSource(99))
 	svc := NewService(rng)
 
-	game, _, err := svc.StartGame([]string{"u1", "u2"}, -1, 0)
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2"}, -1, 0, testDeck())
 	if err != nil {
 		t.Fatalf("start game error: %v", err)
 	}
@@ -120,7 +148,7 @@ func TestPlayCardsAndEnd(t *testing.T) {
 func TestPassAndRoundReset(t *testing.T) {
 	svc := NewService(nil)
 	players := []string{"u1", "u2", "u3"}
-	game, _, _ := svc.StartGame(players, -1, 0)
+	game, _, _ := svc.StartGameWithDeck(players, -1, 0, testDeck())
 
 	// Force hands to ensure they don't finish immediately
 	for _, p := range game.Players {
@@ -172,7 +200,7 @@ func TestPassAndRoundReset(t *testing.T) {
 func TestPlayErrors(t *testing.T) {
 	svc := NewService(nil)
 	players := []string{"u1", "u2"}
-	game, _, _ := svc.StartGame(players, -1, 0)
+	game, _, _ := svc.StartGameWithDeck(players, -1, 0, testDeck())
 
 	game.Players["u1"].Hand = []domain.Card{{Suit: 0, Rank: 0}} // 3 Spades
 	game.CurrentTurn = 0                                        // u1
@@ -203,11 +231,12 @@ func TestPlayErrors(t *testing.T) {
 func TestRoundResetsWhenLastPlayerFinishes(t *testing.T) {
 	svc := NewService(nil)
 	players := []string{"u1", "u2", "u3"}
-	game, _, _ := svc.StartGame(players, -1, 0)
+	game, _, _ := svc.StartGameWithDeck(players, -1, 0, testDeck())
 
 	// Force u1 to have only one card
 	game.Players["u1"].Hand = []domain.Card{{Suit: 3, Rank: 12}} // 2 Hearts
 	game.CurrentTurn = 0                                         // u1
+	markAllPlayed(game)
 
 	// u1 plays their last card and finishes
 	_, err := svc.PlayCards(game, 0, []domain.Card{{Suit: 3, Rank: 12}})
@@ -248,7 +277,7 @@ func TestRoundResetsWhenLastPlayerFinishes(t *testing.T) {
 func TestNextPlayerAfterFinisherAndPasses(t *testing.T) {
 	svc := NewService(nil)
 	players := []string{"p1", "p2", "p3"}
-	game, _, err := svc.StartGame(players, -1, 0)
+	game, _, err := svc.StartGameWithDeck(players, -1, 0, testDeck())
 	if err != nil {
 		t.Fatalf("start game error: %v", err)
 	}
@@ -267,6 +296,7 @@ func TestNextPlayerAfterFinisherAndPasses(t *testing.T) {
 	}
 
 	game.CurrentTurn = 1
+	markAllPlayed(game)
 
 	// Player 2 plays both 2s and finishes.
 	_, err = svc.PlayCards(game, 1, []domain.Card{
@@ -311,7 +341,7 @@ func TestNextPlayerAfterFinisherAndPasses(t *testing.T) {
 func TestTimeoutTurn(t *testing.T) {
 	svc := NewService(nil)
 	players := []string{"u1", "u2"}
-	game, _, _ := svc.StartGame(players, -1, 0)
+	game, _, _ := svc.StartGameWithDeck(players, -1, 0, testDeck())
 
 	// Case 1: New Round (Leader) Timeout -> Play Smallest Card
 	game.LastPlayedCombination = domain.CardCombination{Type: domain.Invalid}
@@ -379,11 +409,12 @@ func TestTimeoutTurn(t *testing.T) {
 func TestPlayerFinishedEvent(t *testing.T) {
 	svc := NewService(nil)
 	players := []string{"u1", "u2", "u3"}
-	game, _, _ := svc.StartGame(players, -1, 0)
+	game, _, _ := svc.StartGameWithDeck(players, -1, 0, testDeck())
 
 	// u1 has 1 card
 	game.Players["u1"].Hand = []domain.Card{{Rank: 0, Suit: 0}}
 	game.CurrentTurn = 0
+	markAllPlayed(game)
 
 	events, err := svc.PlayCards(game, 0, []domain.Card{{Rank: 0, Suit: 0}})
 	if err != nil {
@@ -408,3 +439,520 @@ func TestPlayerFinishedEvent(t *testing.T) {
 		t.Fatal("EventPlayerFinished not found in events")
 	}
 }
+
+func TestStartGameWithDeck_InstantWinEndsGame(t *testing.T) {
+	svc := NewService(nil)
+
+	// Seat 1 is dealt four 2s; seat 0 gets an ordinary hand.
+	deck := []domain.Card{
+		{Rank: 0, Suit: 0}, {Rank: 0, Suit: 1}, {Rank: 1, Suit: 0}, {Rank: 2, Suit: 1}, {Rank: 3, Suit: 2},
+		{Rank: 4, Suit: 3}, {Rank: 5, Suit: 0}, {Rank: 6, Suit: 1}, {Rank: 7, Suit: 2}, {Rank: 8, Suit: 3},
+		{Rank: 9, Suit: 0}, {Rank: 10, Suit: 1}, {Rank: 0, Suit: 2},
+		{Rank: 12, Suit: 0}, {Rank: 12, Suit: 1}, {Rank: 12, Suit: 2}, {Rank: 12, Suit: 3}, {Rank: 1, Suit: 1},
+		{Rank: 2, Suit: 2}, {Rank: 3, Suit: 3}, {Rank: 4, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 6, Suit: 2},
+		{Rank: 7, Suit: 3}, {Rank: 8, Suit: 0}, {Rank: 9, Suit: 1},
+	}
+
+	game, evs, err := svc.StartGameWithDeck([]string{"u1", "u2"}, -1, 100, deck)
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	if game.Phase != domain.PhaseEnded {
+		t.Fatalf("phase = %s, want ended", game.Phase)
+	}
+	if game.InstantWin != domain.InstantWinFourTwos {
+		t.Fatalf("instant win = %q, want %q", game.InstantWin, domain.InstantWinFourTwos)
+	}
+
+	var foundInstantWin, foundEnd bool
+	for _, ev := range evs {
+		switch ev.Kind {
+		case EventInstantWin:
+			foundInstantWin = true
+			payload := ev.Payload.(InstantWinPayload)
+			if payload.Seat != 1 {
+				t.Errorf("instant win seat = %d, want 1", payload.Seat)
+			}
+		case EventGameEnded:
+			foundEnd = true
+			payload := ev.Payload.(GameEndedPayload)
+			if len(payload.FinishOrderSeats) != 2 || payload.FinishOrderSeats[0] != 1 {
+				t.Errorf("finish order = %v, want winner seat 1 first", payload.FinishOrderSeats)
+			}
+			if payload.BalanceChanges["u2"] <= 0 || payload.BalanceChanges["u1"] >= 0 {
+				t.Errorf("unexpected balance changes: %v", payload.BalanceChanges)
+			}
+		}
+	}
+	if !foundInstantWin || !foundEnd {
+		t.Fatalf("expected instant win and game ended events, got %+v", evs)
+	}
+}
+
+func TestPlayCards_GameEndedIncludesLeftoverPenalties(t *testing.T) {
+	svc := NewService(nil)
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+
+	// u2 is left holding a red 2 when u1 plays out.
+	game.Players["u1"].Hand = []domain.Card{{Rank: 0, Suit: 0}}
+	game.Players["u2"].Hand = []domain.Card{{Rank: 12, Suit: 3}, {Rank: 1, Suit: 0}}
+	game.CurrentTurn = 0
+	markAllPlayed(game)
+
+	events, err := svc.PlayCards(game, 0, []domain.Card{{Rank: 0, Suit: 0}})
+	if err != nil {
+		t.Fatalf("PlayCards error: %v", err)
+	}
+
+	var ended *GameEndedPayload
+	for _, ev := range events {
+		if ev.Kind == EventGameEnded {
+			p := ev.Payload.(GameEndedPayload)
+			ended = &p
+		}
+	}
+	if ended == nil {
+		t.Fatal("EventGameEnded not found in events")
+	}
+	if len(ended.Penalties) != 1 || ended.Penalties[0].Reason != domain.PenaltyRedPig {
+		t.Fatalf("penalties = %+v, want one red pig", ended.Penalties)
+	}
+	if ended.Penalties[0].PayerSeat != 1 || ended.Penalties[0].PayeeSeat != 0 {
+		t.Errorf("penalty paid %d -> %d, want 1 -> 0", ended.Penalties[0].PayerSeat, ended.Penalties[0].PayeeSeat)
+	}
+	// Rank payout 100 plus red pig 200, loser is not taxed.
+	if ended.BalanceChanges["u2"] != -300 {
+		t.Errorf("loser change = %d, want -300", ended.BalanceChanges["u2"])
+	}
+}
+
+func TestPlayCards_FreezesPlayersWhoNeverPlayed(t *testing.T) {
+	svc := NewService(nil)
+	players := []string{"u1", "u2", "u3"}
+	game, _, err := svc.StartGameWithDeck(players, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+
+	// u2 has played once; u3 has only ever passed.
+	game.Players["u1"].Hand = []domain.Card{{Rank: 0, Suit: 0}}
+	game.Players["u2"].HasPlayed = true
+	game.CurrentTurn = 0
+
+	events, err := svc.PlayCards(game, 0, []domain.Card{{Rank: 0, Suit: 0}})
+	if err != nil {
+		t.Fatalf("PlayCards error: %v", err)
+	}
+
+	if !game.Players["u3"].Frozen || game.Players["u2"].Frozen {
+		t.Fatalf("expected only u3 frozen, got u2=%v u3=%v", game.Players["u2"].Frozen, game.Players["u3"].Frozen)
+	}
+
+	var frozenEvent, ended bool
+	for _, ev := range events {
+		switch ev.Kind {
+		case EventPlayerFinished:
+			p := ev.Payload.(PlayerFinishedPayload)
+			if p.Frozen {
+				frozenEvent = true
+				if p.Seat != 2 || p.Rank != 3 {
+					t.Errorf("frozen finish = seat %d rank %d, want seat 2 rank 3", p.Seat, p.Rank)
+				}
+			}
+		case EventGameEnded:
+			ended = true
+			p := ev.Payload.(GameEndedPayload)
+			if len(p.FrozenSeats) != 1 || p.FrozenSeats[0] != 2 {
+				t.Errorf("frozen seats = %v, want [2]", p.FrozenSeats)
+			}
+			if len(p.FinishOrderSeats) != 3 || p.FinishOrderSeats[2] != 2 {
+				t.Errorf("finish order = %v, want frozen seat 2 last", p.FinishOrderSeats)
+			}
+		}
+	}
+	if !frozenEvent {
+		t.Error("expected a frozen PlayerFinished event")
+	}
+	// Only u2 is left holding playable cards, so the game ends.
+	if !ended || game.Phase != domain.PhaseEnded {
+		t.Errorf("expected game to end, phase = %s", game.Phase)
+	}
+}
+
+func TestPlayCards_UsesServiceRuleSet(t *testing.T) {
+	svc := NewService(nil, WithRuleSet(domain.NorthernRules{}))
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	if game.RuleSet().Name() != domain.RuleSetNorthern {
+		t.Fatalf("rule set = %s, want northern", game.RuleSet().Name())
+	}
+	markAllPlayed(game)
+
+	quad := []domain.Card{{Rank: 5, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 5, Suit: 2}, {Rank: 5, Suit: 3}}
+	game.Players["u1"].Hand = []domain.Card{{Rank: 12, Suit: 3}, {Rank: 0, Suit: 0}}
+	game.Players["u2"].Hand = append([]domain.Card{{Rank: 1, Suit: 0}}, quad...)
+	game.CurrentTurn = 0
+
+	if _, err := svc.PlayCards(game, 0, []domain.Card{{Rank: 12, Suit: 3}}); err != nil {
+		t.Fatalf("play 2 error: %v", err)
+	}
+	// A quad chops a 2 under Southern rules, but not under Northern rules.
+	if _, err := svc.PlayCards(game, 1, quad); err != ErrCannotBeat {
+		t.Fatalf("quad on a 2 error = %v, want ErrCannotBeat", err)
+	}
+}
+
+func TestPlayCards_StackedChopPaysAccumulatedChain(t *testing.T) {
+	svc := NewService(nil)
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2", "u3"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	markAllPlayed(game)
+
+	pine := []domain.Card{
+		{Rank: 0, Suit: 0}, {Rank: 0, Suit: 1},
+		{Rank: 1, Suit: 0}, {Rank: 1, Suit: 1},
+		{Rank: 2, Suit: 0}, {Rank: 2, Suit: 1},
+	}
+	quad := []domain.Card{{Rank: 7, Suit: 0}, {Rank: 7, Suit: 1}, {Rank: 7, Suit: 2}, {Rank: 7, Suit: 3}}
+	game.Players["u1"].Hand = []domain.Card{{Rank: 12, Suit: 3}, {Rank: 5, Suit: 0}}
+	game.Players["u2"].Hand = append([]domain.Card{{Rank: 6, Suit: 0}}, pine...)
+	game.Players["u3"].Hand = append([]domain.Card{{Rank: 6, Suit: 1}}, quad...)
+	game.CurrentTurn = 0
+
+	if _, err := svc.PlayCards(game, 0, []domain.Card{{Rank: 12, Suit: 3}}); err != nil {
+		t.Fatalf("play red pig error: %v", err)
+	}
+
+	chopped := func(events []Event) PigChoppedPayload {
+		t.Helper()
+		for _, ev := range events {
+			if ev.Kind == EventPigChopped {
+				return ev.Payload.(PigChoppedPayload)
+			}
+		}
+		t.Fatal("EventPigChopped not found in events")
+		return PigChoppedPayload{}
+	}
+
+	// u2 chops the red pig (2 units) with a 3-pine.
+	events, err := svc.PlayCards(game, 1, pine)
+	if err != nil {
+		t.Fatalf("3-pine chop error: %v", err)
+	}
+	first := chopped(events)
+	if first.BalanceChanges["u2"] != 200 || first.BalanceChanges["u1"] != -200 {
+		t.Errorf("first chop balance changes = %v, want u2 +200, u1 -200", first.BalanceChanges)
+	}
+	if len(first.Chain) != 2 {
+		t.Fatalf("first chop chain length = %d, want 2", len(first.Chain))
+	}
+
+	// u3 over-chops with a quad: u2 pays the 3-pine (3 units) plus the pig they collected.
+	events, err = svc.PlayCards(game, 2, quad)
+	if err != nil {
+		t.Fatalf("quad over-chop error: %v", err)
+	}
+	second := chopped(events)
+	if second.BalanceChanges["u3"] != 500 || second.BalanceChanges["u2"] != -500 {
+		t.Errorf("over-chop balance changes = %v, want u3 +500, u2 -500", second.BalanceChanges)
+	}
+	wantSeats := []int{0, 1, 2}
+	if len(second.Chain) != len(wantSeats) {
+		t.Fatalf("over-chop chain = %+v, want seats %v", second.Chain, wantSeats)
+	}
+	for i, link := range second.Chain {
+		if link.Seat != wantSeats[i] {
+			t.Errorf("chain[%d].Seat = %d, want %d", i, link.Seat, wantSeats[i])
+		}
+	}
+	if second.Chain[2].ChopType != "Quad" || second.Chain[2].Amount != 500 {
+		t.Errorf("last link = %+v, want Quad for 500", second.Chain[2])
+	}
+
+	// Everyone passes: the round resets and so does the chain.
+	if _, err := svc.PassTurn(game, 0); err != nil {
+		t.Fatalf("pass error: %v", err)
+	}
+	if _, err := svc.PassTurn(game, 1); err != nil {
+		t.Fatalf("pass error: %v", err)
+	}
+	if len(game.ChopChain) != 0 {
+		t.Errorf("chop chain not reset on new round: %+v", game.ChopChain)
+	}
+}
+
+func TestPlayCards_RequiresOpeningCardOnFreshTable(t *testing.T) {
+	svc := NewService(nil, WithRuleSet(domain.SouthernRules{RuleOptions: domain.RuleOptions{OpeningCard: true}}))
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2", "u3", "u4"}, -1, 0, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	threeOfSpades := domain.Card{Rank: 0, Suit: 0}
+	if game.OpeningCard == nil || *game.OpeningCard != threeOfSpades {
+		t.Fatalf("opening card = %v, want 3 of Spades", game.OpeningCard)
+	}
+
+	var leader *domain.Player
+	for _, p := range game.Players {
+		if p.Seat == game.CurrentTurn {
+			leader = p
+		}
+	}
+	var other domain.Card
+	for _, c := range leader.Hand {
+		if c != threeOfSpades {
+			other = c
+			break
+		}
+	}
+	if _, err := svc.PlayCards(game, leader.Seat, []domain.Card{other}); err != ErrOpeningCardRequired {
+		t.Fatalf("play without opening card error = %v, want ErrOpeningCardRequired", err)
+	}
+
+	// A timeout on the opening lead plays the opening card and lifts the requirement.
+	if _, err := svc.TimeoutTurn(game, leader.Seat); err != nil {
+		t.Fatalf("timeout turn error: %v", err)
+	}
+	if game.OpeningCard != nil {
+		t.Error("expected opening card to be cleared after the first play")
+	}
+	if len(game.LastPlayedCombination.Cards) != 1 || game.LastPlayedCombination.Cards[0] != threeOfSpades {
+		t.Errorf("last played = %v, want the 3 of Spades", game.LastPlayedCombination.Cards)
+	}
+}
+
+func TestStartGame_OpeningCardOnlyOnFreshTable(t *testing.T) {
+	players := []string{"u1", "u2", "u3", "u4"}
+
+	svc := NewService(nil, WithRuleSet(domain.SouthernRules{RuleOptions: domain.RuleOptions{OpeningCard: true}}))
+	game, _, _ := svc.StartGameWithDeck(players, 2, 0, testDeck())
+	if game.OpeningCard != nil {
+		t.Error("expected no opening card when the last winner leads")
+	}
+
+	game, _, _ = NewService(nil).StartGameWithDeck(players, -1, 0, testDeck())
+	if game.OpeningCard != nil {
+		t.Error("expected no opening card when the rule is disabled")
+	}
+}
+
+func TestInvariantChecks_FullGameStaysValid(t *testing.T) {
+	svc := NewService(nil, WithInvariantChecks(true))
+
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2", "u3", "u4"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+
+	// Timeouts lead with the smallest card and pass otherwise, which always finishes the game.
+	for steps := 0; game.Phase == domain.PhasePlaying; steps++ {
+		if steps > 1000 {
+			t.Fatalf("game did not end")
+		}
+		if _, err := svc.TimeoutTurn(game, game.CurrentTurn); err != nil {
+			t.Fatalf("timeout turn error at step %d: %v", steps, err)
+		}
+	}
+}
+
+func TestInvariantChecks_FailFastOnCorruptState(t *testing.T) {
+	svc := NewService(nil, WithInvariantChecks(true))
+
+	game, _, err := svc.StartGameWithDeck([]string{"u1", "u2"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	game.OpeningCard = nil
+
+	// Duplicate a card from the other hand into the player on turn.
+	var actor, other *domain.Player
+	for _, p := range game.Players {
+		if p.Seat == game.CurrentTurn {
+			actor = p
+		} else {
+			other = p
+		}
+	}
+	actor.Hand = append(actor.Hand, other.Hand[0])
+
+	_, err = svc.PlayCards(game, actor.Seat, []domain.Card{actor.Hand[0]})
+	if !errors.Is(err, domain.ErrInvariantViolation) {
+		t.Fatalf("play cards error = %v, want %v", err, domain.ErrInvariantViolation)
+	}
+}
+
+func TestStartGameWithSeed_CommitsAndReveals(t *testing.T) {
+	svc := NewService(rand.New(rand.NewSource(7)))
+	serverSeed, err := svc.NewServerSeed()
+	if err != nil {
+		t.Fatalf("server seed error: %v", err)
+	}
+	seed := domain.DealSeed{ServerSeed: serverSeed, ClientSalt: "player-salt"}
+
+	game, evs, err := svc.StartGameWithSeed([]string{"u1", "", "u3"}, -1, 100, seed)
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+
+	for _, ev := range evs {
+		if ev.Kind == EventGameStarted {
+			if got := ev.Payload.(GameStartedPayload).SeedCommitment; got != seed.Commitment() {
+				t.Fatalf("game started commitment = %q, want %q", got, seed.Commitment())
+			}
+		}
+	}
+
+	// Finish the game and check the reveal recomputes both hands.
+	dealt := map[string][]domain.Card{}
+	for uid, p := range game.Players {
+		dealt[uid] = append([]domain.Card(nil), p.Hand...)
+	}
+	game.Phase = domain.PhaseEnded
+	game.FinishOrderSeats = []int{0, 2}
+	ended := svc.buildGameEndedPayload(game)
+
+	deck, err := domain.VerifyDeal(ended.ServerSeed, ended.ClientSalt, ended.SeedCommitment)
+	if err != nil {
+		t.Fatalf("verify deal error: %v", err)
+	}
+	for i, uid := range []string{"u1", "u3"} {
+		block := append([]domain.Card(nil), deck[i*13:i*13+13]...)
+		domain.SortHand(block)
+		for j := range block {
+			if block[j] != dealt[uid][j] {
+				t.Fatalf("%s card %d = %v, recomputed %v", uid, j, dealt[uid][j], block[j])
+			}
+		}
+	}
+}
+
+func TestNewServerSeed_ReproducibleWithRng(t *testing.T) {
+	a, _ := NewService(rand.New(rand.NewSource(3))).NewServerSeed()
+	b, _ := NewService(rand.New(rand.NewSource(3))).NewServerSeed()
+	if string(a) != string(b) {
+		t.Fatalf("seeds from identical rngs differ")
+	}
+
+	c, err := NewService(nil).NewServerSeed()
+	if err != nil || len(c) != domain.ServerSeedSize {
+		t.Fatalf("crypto seed = %d bytes, err %v", len(c), err)
+	}
+}
+
+func TestLeaveGame_OnOwnTurnMovesTurnAndRanksLeaverLast(t *testing.T) {
+	svc := NewService(nil, WithInvariantChecks(true))
+	game, _, err := svc.StartGameWithDeck([]string{"p1", "p2", "p3"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	leaver := game.CurrentTurn
+	var leaverID string
+	for uid, p := range game.Players {
+		if p.Seat == leaver {
+			leaverID = uid
+		}
+	}
+
+	events, err := svc.LeaveGame(game, leaver)
+	if err != nil {
+		t.Fatalf("leave error: %v", err)
+	}
+	if len(events) != 1 || events[0].Kind != EventTurnPassed {
+		t.Fatalf("expected a single TurnPassed event, got %v", events)
+	}
+	passed := events[0].Payload.(TurnPassedPayload)
+	if passed.Seat != leaver || passed.NextTurnSeat == leaver || passed.NewRound {
+		t.Fatalf("unexpected TurnPassed payload %+v", passed)
+	}
+	if game.CurrentTurn != passed.NextTurnSeat || !game.Players[leaverID].Forfeited {
+		t.Fatalf("expected the turn to move on and %s to forfeit", leaverID)
+	}
+	if _, err := svc.LeaveGame(game, leaver); !errors.Is(err, ErrPlayerFinished) {
+		t.Fatalf("expected a second leave to fail with ErrPlayerFinished, got %v", err)
+	}
+
+	// The other two play it out; the leaver is never on turn again.
+	var ended []Event
+	for steps := 0; game.Phase == domain.PhasePlaying; steps++ {
+		if steps > 1000 {
+			t.Fatalf("game did not end")
+		}
+		if game.CurrentTurn == leaver {
+			t.Fatalf("turn returned to the leaver's seat %d", leaver)
+		}
+		if ended, err = svc.TimeoutTurn(game, game.CurrentTurn); err != nil {
+			t.Fatalf("timeout turn error at step %d: %v", steps, err)
+		}
+	}
+
+	if last := game.FinishOrderSeats[len(game.FinishOrderSeats)-1]; last != leaver {
+		t.Fatalf("expected the leaver last in %v", game.FinishOrderSeats)
+	}
+	payload := ended[len(ended)-1].Payload.(GameEndedPayload)
+	if payload.BalanceChanges[leaverID] >= 0 {
+		t.Errorf("expected the leaver to pay, change %d", payload.BalanceChanges[leaverID])
+	}
+}
+
+func TestLeaveGame_OnOwnTurnMidRoundHandsRoundToLastPlayer(t *testing.T) {
+	svc := NewService(nil)
+	game, _, err := svc.StartGameWithDeck([]string{"p1", "p2", "p3"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	game.Players["p1"].Hand = []domain.Card{{Suit: 0, Rank: 5}, {Suit: 1, Rank: 6}}
+	game.Players["p2"].Hand = []domain.Card{{Suit: 0, Rank: 2}, {Suit: 1, Rank: 3}}
+	game.Players["p3"].Hand = []domain.Card{{Suit: 2, Rank: 6}, {Suit: 3, Rank: 8}}
+	game.CurrentTurn = 0
+	game.OpeningCard = nil
+	markAllPlayed(game)
+
+	if _, err := svc.PlayCards(game, 0, []domain.Card{{Suit: 0, Rank: 5}}); err != nil {
+		t.Fatalf("p1 play error: %v", err)
+	}
+	if _, err := svc.PassTurn(game, 1); err != nil {
+		t.Fatalf("p2 pass error: %v", err)
+	}
+
+	// p3 leaves on their turn: only p1 is left in the round, so p1 leads a new one.
+	events, err := svc.LeaveGame(game, 2)
+	if err != nil {
+		t.Fatalf("leave error: %v", err)
+	}
+	passed := events[0].Payload.(TurnPassedPayload)
+	if !passed.NewRound || game.CurrentTurn != 0 || game.LastPlayedCombination.Type != domain.Invalid {
+		t.Fatalf("expected a new round led by seat 0, got %+v (turn %d)", passed, game.CurrentTurn)
+	}
+	if game.Players["p2"].HasPassed {
+		t.Errorf("expected passes to reset for the new round")
+	}
+}
+
+func TestLeaveGame_LastOpponentLeavingEndsGame(t *testing.T) {
+	svc := NewService(nil, WithInvariantChecks(true))
+	game, _, err := svc.StartGameWithDeck([]string{"p1", "p2"}, -1, 100, testDeck())
+	if err != nil {
+		t.Fatalf("start game error: %v", err)
+	}
+	stayer := game.CurrentTurn
+	leaver := 1 - stayer
+
+	events, err := svc.LeaveGame(game, leaver)
+	if err != nil {
+		t.Fatalf("leave error: %v", err)
+	}
+	if len(events) != 1 || events[0].Kind != EventGameEnded || game.Phase != domain.PhaseEnded {
+		t.Fatalf("expected the game to end, got %v", events)
+	}
+	payload := events[0].Payload.(GameEndedPayload)
+	if len(payload.FinishOrderSeats) != 2 || payload.FinishOrderSeats[0] != stayer || payload.FinishOrderSeats[1] != leaver {
+		t.Fatalf("expected finish order [%d %d], got %v", stayer, leaver, payload.FinishOrderSeats)
+	}
+}
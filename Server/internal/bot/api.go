@@ -12,7 +12,8 @@ type Move struct {
 }
 
 // Brain is the interface that all bot strategies must implement.
+// Strategies only see the game through the bot's PlayerView, i.e. what a human in its seat could see.
 type Brain interface {
-	CalculateMove(game *domain.Game, player *domain.Player) (Move, error)
+	CalculateMove(view domain.PlayerView) (Move, error)
 	OnEvent(payload interface{}, isRecipient bool)
 }
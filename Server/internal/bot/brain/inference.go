@@ -32,20 +32,8 @@ func (e *Estimator) LeadTurnProbability(c domain.Card) float64 {
 		return 1.0
 	}
 
-	// Count how many cards are higher and where they are
-	higherUnknown := 0
-	higherTotal := 0
-	idx := cardToIndex(c)
-	
-	for i := idx + 1; i < 52; i++ {
-		status := e.Memory.DeckStatus[i]
-		if status == StatusUnknown || status == StatusOpponent {
-			higherUnknown++
-			higherTotal++
-		} else if status == StatusPlayed {
-			higherTotal++
-		}
-	}
+	// Count how many cards higher than c may still be with opponents
+	higherUnknown := e.Memory.Unseen().Above(c).Len()
 
 	if higherUnknown == 0 {
 		return 1.0
@@ -66,17 +54,15 @@ func (e *Estimator) CalculateDominance(hand []domain.Card) float64 {
 
 	handPower := 0.0
 	for _, c := range hand {
-		handPower += float64(cardToIndex(c))
+		handPower += float64(domain.CardPower(c))
 	}
 	avgHandPower := handPower / float64(len(hand))
 
 	unknownPower := 0.0
-	unknownCount := 0
-	for i, status := range e.Memory.DeckStatus {
-		if status == StatusUnknown || status == StatusOpponent {
-			unknownPower += float64(i)
-			unknownCount++
-		}
+	unseen := e.Memory.Unseen()
+	unknownCount := unseen.Len()
+	for _, c := range unseen.Cards() {
+		unknownPower += float64(domain.CardPower(c))
 	}
 
 	if unknownCount == 0 {
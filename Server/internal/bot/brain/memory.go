@@ -15,9 +15,11 @@ const (
 )
 
 // GameMemory stores the bot's private "view" of the game.
+// Each card is in at most one of Mine, Played and Opponent; cards in none of them are StatusUnknown.
 type GameMemory struct {
-	// DeckStatus tracks all 52 cards. Index = Rank*4 + Suit.
-	DeckStatus [52]CardStatus
+	Mine     domain.CardSet // In the bot's hand
+	Played   domain.CardSet // Already on the table (discarded)
+	Opponent domain.CardSet // Inferred to be in an opponent's hand
 	// Opponents tracks behavioral profiles by seat index.
 	Opponents map[int]*OpponentProfile
 	// CurrentCombo represents the combination currently on the table to beat.
@@ -33,9 +35,7 @@ func NewMemory() *GameMemory {
 
 // Reset clears the memory for a new game.
 func (m *GameMemory) Reset() {
-	for i := range m.DeckStatus {
-		m.DeckStatus[i] = StatusUnknown
-	}
+	m.Mine, m.Played, m.Opponent = 0, 0, 0
 	// Reset opponent profiles
 	for seat, p := range m.Opponents {
 		p.Weaknesses = make(map[domain.CardCombinationType]int32)
@@ -48,34 +48,29 @@ func (m *GameMemory) Reset() {
 
 // MarkMine records the cards currently in the bot's hand.
 func (m *GameMemory) MarkMine(cards []domain.Card) {
-	for _, c := range cards {
-		m.DeckStatus[cardToIndex(c)] = StatusMine
-	}
+	set := domain.NewCardSet(cards)
+	m.Played, m.Opponent = m.Played.Minus(set), m.Opponent.Minus(set)
+	m.Mine = m.Mine.Union(set)
 }
 
 // MarkPlayed records cards that have been played on the table.
 func (m *GameMemory) MarkPlayed(cards []domain.Card) {
-	for _, c := range cards {
-		m.DeckStatus[cardToIndex(c)] = StatusPlayed
-	}
+	set := domain.NewCardSet(cards)
+	m.Mine, m.Opponent = m.Mine.Minus(set), m.Opponent.Minus(set)
+	m.Played = m.Played.Union(set)
 }
 
 // MarkOpponent records cards inferred to be with opponents.
 func (m *GameMemory) MarkOpponent(cards []domain.Card) {
-	for _, c := range cards {
-		m.DeckStatus[cardToIndex(c)] = StatusOpponent
-	}
+	set := domain.NewCardSet(cards)
+	m.Mine, m.Played = m.Mine.Minus(set), m.Played.Minus(set)
+	m.Opponent = m.Opponent.Union(set)
 }
 
 // UpdateHand synchronization. Marks current hand as Mine and others that were Mine as Unknown.
 func (m *GameMemory) UpdateHand(hand []domain.Card) {
-	// First, revert current Mine cards to Unknown (assuming they were played or shifted)
-	for i, status := range m.DeckStatus {
-		if status == StatusMine {
-			m.DeckStatus[i] = StatusUnknown
-		}
-	}
-	// Mark new hand
+	// Cards no longer in hand were played or shifted; forget them until seen again.
+	m.Mine = 0
 	m.MarkMine(hand)
 }
 
@@ -123,24 +118,30 @@ func (m *GameMemory) RecordPass(seat int) {
 	p.RecordFailure(m.CurrentCombo)
 }
 
+// Status returns what the bot knows about c.
+func (m *GameMemory) Status(c domain.Card) CardStatus {
+	switch {
+	case m.Mine.Has(c):
+		return StatusMine
+	case m.Played.Has(c):
+		return StatusPlayed
+	case m.Opponent.Has(c):
+		return StatusOpponent
+	}
+	return StatusUnknown
+}
+
+// Unseen returns the cards that may still be in an opponent's hand (StatusUnknown or StatusOpponent).
+func (m *GameMemory) Unseen() domain.CardSet {
+	return domain.FullDeck.Minus(m.Mine).Minus(m.Played)
+}
+
 // IsBoss returns true if no higher card exists in an unknown or opponent hand.
 func (m *GameMemory) IsBoss(c domain.Card) bool {
-	idx := cardToIndex(c)
-	for i := idx + 1; i < 52; i++ {
-		if m.DeckStatus[i] == StatusUnknown || m.DeckStatus[i] == StatusOpponent {
-			return false
-		}
-	}
-	return true
+	return m.Unseen().Above(c).IsEmpty()
 }
 
 // IsPlayed returns true if the card is already out of the game.
 func (m *GameMemory) IsPlayed(c domain.Card) bool {
-	return m.DeckStatus[cardToIndex(c)] == StatusPlayed
+	return m.Played.Has(c)
 }
-
-// cardToIndex converts domain.Card to a 0-51 index.
-// Rank: 0 (3) to 12 (2). Suit: 0 (Spades) to 3 (Hearts).
-func cardToIndex(c domain.Card) int {
-	return int(c.Rank)*4 + int(c.Suit)
-}
\ No newline at end of file
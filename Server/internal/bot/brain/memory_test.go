@@ -9,22 +9,22 @@ func TestGameMemory(t *testing.T) {
 	m := NewMemory()
 
 	// Initial state
-	for i := 0; i < 52; i++ {
-		if m.DeckStatus[i] != StatusUnknown {
-			t.Errorf("Index %d should be Unknown, got %d", i, m.DeckStatus[i])
+	for _, c := range domain.NewDeck() {
+		if m.Status(c) != StatusUnknown {
+			t.Errorf("%s should be Unknown, got %d", c, m.Status(c))
 		}
 	}
 
 	// Mark Mine: 3 of Spades (0,0)
 	threeSpades := domain.Card{Rank: 0, Suit: 0}
 	m.MarkMine([]domain.Card{threeSpades})
-	if m.DeckStatus[0] != StatusMine {
+	if m.Status(threeSpades) != StatusMine {
 		t.Errorf("3S should be StatusMine")
 	}
 
 	// Reset
 	m.Reset()
-	if m.DeckStatus[0] != StatusUnknown {
+	if m.Status(threeSpades) != StatusUnknown {
 		t.Errorf("After reset, 3S should be StatusUnknown")
 	}
 }
@@ -72,3 +72,16 @@ func TestGameMemory_OpponentModeling(t *testing.T) {
 		t.Errorf("Weakness should have increased to Pair of Queens value")
 	}
 }
+
+func BenchmarkGameMemory_IsBoss(b *testing.B) {
+	m := NewMemory()
+	deck := domain.NewDeck()
+	m.MarkMine(deck[:13])
+	m.MarkPlayed(deck[13:30])
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, c := range deck[:13] {
+			m.IsBoss(c)
+		}
+	}
+}
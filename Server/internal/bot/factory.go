@@ -2,6 +2,7 @@ package bot
 
 import (
 	"tienlen/internal/bot/brain"
+	"tienlen/internal/domain"
 )
 
 // NewAgent creates a new autonomous bot agent with the configured identity.
@@ -22,4 +23,32 @@ func NewAgent(botID string) (*Agent, error) {
 			Estimator: brain.NewEstimator(mem),
 		},
 	}, nil
-}
\ No newline at end of file
+}
+
+// AutopilotName is the Agent.Name of agents playing for a disconnected human.
+const AutopilotName = "Autopilot"
+
+// NewAutopilot creates an agent that plays a human's seat while they are disconnected.
+// The agent takes the human's user ID from their view, so it receives their private events,
+// and its memory is seeded from the game so far.
+func NewAutopilot(view domain.PlayerView) *Agent {
+	mem := memoryFromView(view)
+	return &Agent{
+		ID:   view.UserID,
+		Name: AutopilotName,
+		Strategy: &StandardBot{
+			Memory:    mem,
+			Estimator: brain.NewEstimator(mem),
+		},
+	}
+}
+
+// memoryFromView returns a memory holding what the viewer has seen so far:
+// their hand, every discarded card and the combination on the table.
+func memoryFromView(view domain.PlayerView) *brain.GameMemory {
+	mem := brain.NewMemory()
+	mem.MarkMine(view.Hand)
+	mem.MarkPlayed(view.Discards)
+	mem.UpdateTable(view.LastPlayedCombination.Cards)
+	return mem
+}
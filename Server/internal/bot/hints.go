@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"tienlen/internal/bot/brain"
+	"tienlen/internal/domain"
+)
+
+// Short reasons attached to hint suggestions.
+const (
+	ReasonFinishesHand    = "plays your last cards"
+	ReasonBreaksBomb      = "breaks up a bomb"
+	ReasonBreaksStraight  = "breaks up a straight"
+	ReasonUnbeatable      = "nobody can beat this card"
+	ReasonOpponentsPassed = "next players passed on this before"
+	ReasonUnderCeiling    = "stays under what the next player passed on"
+	ReasonChops           = "chops the table"
+	ReasonSavesCards      = "saves your cards for a better moment"
+	ReasonBestShape       = "leaves your hand in the best shape"
+)
+
+// Suggestion is a move recommended to a human player.
+type Suggestion struct {
+	Pass    bool
+	Cards   []domain.Card
+	Score   float64
+	Reasons []string
+}
+
+// Advisor suggests moves to a human player with the StandardBot scoring pipeline.
+// Feed it game events with OnGameEvent, like an Agent, so its memory follows the table.
+type Advisor struct {
+	UserID string
+	Brain  *StandardBot
+}
+
+// NewAdvisor creates an advisor for the viewing player and seeds its memory from the game so far:
+// the player's hand, every discarded card and the combination on the table.
+func NewAdvisor(view domain.PlayerView) *Advisor {
+	mem := memoryFromView(view)
+	return &Advisor{
+		UserID: view.UserID,
+		Brain:  &StandardBot{Memory: mem, Estimator: brain.NewEstimator(mem)},
+	}
+}
+
+// OnGameEvent notifies the advisor of a game event.
+func (a *Advisor) OnGameEvent(payload interface{}, recipients []string) {
+	isRecipient := len(recipients) == 0
+	for _, r := range recipients {
+		if r == a.UserID {
+			isRecipient = true
+			break
+		}
+	}
+	a.Brain.OnEvent(payload, isRecipient)
+}
+
+// Suggest returns up to limit moves for the advised player, best first.
+// When responding and the bot would rather keep its hand, passing is suggested first.
+// It returns nil when the view is not the advised player's or they have no cards.
+func (a *Advisor) Suggest(view domain.PlayerView, limit int) []Suggestion {
+	if view.UserID != a.UserID || len(view.Hand) == 0 || limit <= 0 {
+		return nil
+	}
+
+	ranked, passScore := a.Brain.rankMoves(view)
+	responding := view.LastPlayedCombination.Type != domain.Invalid
+
+	var suggestions []Suggestion
+	if responding && (len(ranked) == 0 || ranked[0].Score < passScore) {
+		suggestions = append(suggestions, Suggestion{Pass: true, Score: passScore, Reasons: []string{ReasonSavesCards}})
+	}
+	for i := 0; i < len(ranked) && len(suggestions) < limit; i++ {
+		m := ranked[i]
+		reasons := m.Reasons
+		if len(reasons) == 0 {
+			reasons = []string{ReasonBestShape}
+		}
+		suggestions = append(suggestions, Suggestion{Cards: m.Move.Cards, Score: m.Score, Reasons: reasons})
+	}
+	return suggestions
+}
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"slices"
+	"testing"
+
+	"tienlen/internal/domain"
+)
+
+func hintGame(t *testing.T, hand, table string) *domain.Game {
+	t.Helper()
+	cards, err := domain.ParseCards(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := &domain.Game{
+		Players:               map[string]*domain.Player{"user-1": {UserID: "user-1", Seat: 0, Hand: cards}},
+		LastPlayedCombination: domain.CardCombination{Type: domain.Invalid},
+		LastPlayerToPlaySeat:  1,
+	}
+	if table != "" {
+		played, err := domain.ParseCards(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		game.LastPlayedCombination = domain.IdentifyCombination(played)
+		game.Discards = played
+	}
+	return game
+}
+
+func TestAdvisor_SuggestsChopWithReason(t *testing.T) {
+	game := hintGame(t, "3S 3C 3D 3H 4S", "2S")
+
+	suggestions := NewAdvisor(game.View(0)).Suggest(game.View(0), 3)
+	if len(suggestions) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	best := suggestions[0]
+	if best.Pass || len(best.Cards) != 4 {
+		t.Fatalf("expected the quad first, got %+v", best)
+	}
+	if !slices.Contains(best.Reasons, ReasonChops) {
+		t.Errorf("expected %q among reasons %v", ReasonChops, best.Reasons)
+	}
+}
+
+func TestAdvisor_SuggestsPassBeforeBreakingBomb(t *testing.T) {
+	game := hintGame(t, "5S 5C 5D 5H", "4S")
+
+	suggestions := NewAdvisor(game.View(0)).Suggest(game.View(0), 2)
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(suggestions))
+	}
+	if !suggestions[0].Pass || !slices.Contains(suggestions[0].Reasons, ReasonSavesCards) {
+		t.Errorf("expected passing first, got %+v", suggestions[0])
+	}
+	if !slices.Contains(suggestions[1].Reasons, ReasonBreaksBomb) {
+		t.Errorf("expected the single 5 to be flagged as breaking the bomb, got %+v", suggestions[1])
+	}
+}
+
+func TestAdvisor_OnlyAdvisesOwnView(t *testing.T) {
+	game := hintGame(t, "3S", "")
+	if got := NewAdvisor(game.View(0)).Suggest(game.View(-1), 3); got != nil {
+		t.Errorf("expected no suggestions from a spectator's view, got %v", got)
+	}
+}
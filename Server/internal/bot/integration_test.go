@@ -40,7 +40,7 @@ func TestStandardBot_TacticalRestraint_ProtectsBomb(t *testing.T) {
 	}
 	bot.Memory.UpdateHand(hand)
 	
-	move, err := bot.CalculateMove(game, player)
+	move, err := bot.CalculateMove(game.View(player.Seat))
 	if err != nil {
 		t.Fatalf("CalculateMove failed: %v", err)
 	}
@@ -86,7 +86,7 @@ func TestStandardBot_MemoryDrivenLead(t *testing.T) {
 		LastPlayedCombination: domain.CardCombination{Type: domain.Invalid}, // Free lead
 	}
 	
-	move, _ := bot.CalculateMove(game, player)
+	move, _ := bot.CalculateMove(game.View(player.Seat))
 	
 	// Because seat 1 is weak to singles, bot should lead with the Single Queen 
 	// rather than the Pair of 3s.
@@ -17,7 +17,7 @@ func TestGetValidMoves_Lead(t *testing.T) {
 		{Rank: 3, Suit: 0}, // 6S
 	}
 	
-	moves := GetValidMoves(hand, domain.CardCombination{Type: domain.Invalid})
+	moves := GetValidMoves(hand, domain.CardCombination{Type: domain.Invalid}, domain.SouthernRules{})
 	
 	// Should find:
 	// 5 singles
@@ -65,7 +65,7 @@ func TestGetValidMoves_BeatingSingle(t *testing.T) {
 		Cards: []domain.Card{{Rank: 2, Suit: 0}},
 	}
 	
-	moves := GetValidMoves(hand, prev)
+	moves := GetValidMoves(hand, prev, domain.SouthernRules{})
 	
 	// Should beat 5S with 8S and 2S. 3S is too low.
 	if len(moves) != 2 {
@@ -91,7 +91,7 @@ func TestGetValidMoves_Chop(t *testing.T) {
 		Cards: []domain.Card{{Rank: 12, Suit: 0}},
 	}
 	
-	moves := GetValidMoves(hand, prev)
+	moves := GetValidMoves(hand, prev, domain.SouthernRules{})
 	
 	// Should find the Quad as a valid move (chopping)
 	foundQuad := false
@@ -106,3 +106,97 @@ func TestGetValidMoves_Chop(t *testing.T) {
 		t.Errorf("Bot failed to find chopping move (Quad) against 2S")
 	}
 }
+
+func TestGetValidMoves_NorthernRules(t *testing.T) {
+	rules := domain.NorthernRules{}
+
+	// Quad Js, a 6-7-8 of Hearts and a 6 of Spades.
+	hand := []domain.Card{
+		{Rank: 8, Suit: 0}, {Rank: 8, Suit: 1}, {Rank: 8, Suit: 2}, {Rank: 8, Suit: 3},
+		{Rank: 3, Suit: 3}, {Rank: 4, Suit: 3}, {Rank: 5, Suit: 3}, {Rank: 3, Suit: 0},
+	}
+
+	// Leading: every generated move, including straights, must be accepted by the rule set.
+	foundSuitedStraight := false
+	for _, m := range GetValidMoves(hand, domain.CardCombination{Type: domain.Invalid}, rules) {
+		if !rules.IsValidSet(m.Cards) {
+			t.Errorf("generated move rejected by northern rules: %v", m.Cards)
+		}
+		if len(m.Cards) == 3 && m.Cards[0].Rank != m.Cards[1].Rank {
+			foundSuitedStraight = true
+		}
+	}
+	if !foundSuitedStraight {
+		t.Error("expected the Hearts straight among lead moves")
+	}
+
+	// Responding to a 2: the quad cannot chop it under northern rules.
+	prev := domain.CardCombination{Type: domain.Single, Cards: []domain.Card{{Rank: 12, Suit: 0}}}
+	if moves := GetValidMoves(hand, prev, rules); len(moves) != 0 {
+		t.Errorf("expected no moves against a 2, got %v", moves)
+	}
+
+	// Responding to a straight: it must follow suit.
+	prev = domain.CardCombination{
+		Type:  domain.Straight,
+		Cards: []domain.Card{{Rank: 0, Suit: 2}, {Rank: 1, Suit: 2}, {Rank: 2, Suit: 2}},
+	}
+	if moves := GetValidMoves(hand, prev, rules); len(moves) != 0 {
+		t.Errorf("expected no moves against a Diamonds straight, got %v", moves)
+	}
+	prev.Cards = []domain.Card{{Rank: 0, Suit: 3}, {Rank: 1, Suit: 3}, {Rank: 2, Suit: 3}}
+	if moves := GetValidMoves(hand, prev, rules); len(moves) != 1 {
+		t.Errorf("expected only the Hearts straight against a Hearts straight, got %v", moves)
+	}
+}
+
+func TestValidMovesFrom_OpeningCard(t *testing.T) {
+	opening := domain.Card{Rank: 0, Suit: 0} // 3S
+	hand := []domain.Card{
+		opening, {Rank: 0, Suit: 2}, {Rank: 1, Suit: 1}, {Rank: 2, Suit: 3}, {Rank: 9, Suit: 0},
+	}
+
+	legal := domain.LegalMoves(hand, domain.CardCombination{Type: domain.Invalid}, domain.SouthernRules{})
+	moves := ValidMovesFrom(hand, legal.Containing(opening))
+	if len(moves) == 0 {
+		t.Fatal("expected moves containing the opening card")
+	}
+	for _, m := range moves {
+		found := false
+		for _, c := range m.Cards {
+			if c == opening {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("move %v does not include the opening card", m.Cards)
+		}
+	}
+}
+
+// benchmarkHand is a 13-card hand with a quad, pairs and straights, so every generator path runs.
+func benchmarkHand(b *testing.B) []domain.Card {
+	hand, err := domain.ParseCards("3S 3C 3D 3H 4S 4D 5C 5H 6S 7D 9H 10S 2C")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return hand
+}
+
+func BenchmarkGetValidMoves_Lead(b *testing.B) {
+	hand := benchmarkHand(b)
+	lead := domain.CardCombination{Type: domain.Invalid}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetValidMoves(hand, lead, domain.SouthernRules{})
+	}
+}
+
+func BenchmarkGetValidMoves_RespondToTwo(b *testing.B) {
+	hand := benchmarkHand(b)
+	two := domain.IdentifyCombination([]domain.Card{{Rank: 12, Suit: 3}})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetValidMoves(hand, two, domain.SouthernRules{})
+	}
+}
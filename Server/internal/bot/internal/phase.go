@@ -15,8 +15,8 @@ const (
 )
 
 // DetectPhase infers the phase based on active players' hand sizes and finish state.
-func DetectPhase(game *domain.Game) GamePhase {
-	if game == nil || len(game.Players) == 0 {
+func DetectPhase(view domain.PlayerView) GamePhase {
+	if len(view.Seats) == 0 {
 		return PhaseMid
 	}
 
@@ -24,19 +24,16 @@ func DetectPhase(game *domain.Game) GamePhase {
 	opening := true
 	end := false
 
-	for _, player := range game.Players {
-		if player == nil {
-			continue
-		}
-		if player.Finished || len(player.Hand) == 0 {
+	for _, player := range view.Seats {
+		if player.Finished || player.CardsRemaining == 0 {
 			end = true
 			continue
 		}
 		activePlayers++
-		if len(player.Hand) != 13 {
+		if player.CardsRemaining != 13 {
 			opening = false
 		}
-		if len(player.Hand) <= 5 {
+		if player.CardsRemaining <= 5 {
 			end = true
 		}
 	}
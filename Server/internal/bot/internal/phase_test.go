@@ -13,7 +13,7 @@ func TestDetectPhase_Opening(t *testing.T) {
 		},
 	}
 
-	if got := DetectPhase(game); got != PhaseOpening {
+	if got := DetectPhase(game.View(0)); got != PhaseOpening {
 		t.Fatalf("DetectPhase = %v, want %v", got, PhaseOpening)
 	}
 }
@@ -26,7 +26,7 @@ func TestDetectPhase_Mid(t *testing.T) {
 		},
 	}
 
-	if got := DetectPhase(game); got != PhaseMid {
+	if got := DetectPhase(game.View(0)); got != PhaseMid {
 		t.Fatalf("DetectPhase = %v, want %v", got, PhaseMid)
 	}
 }
@@ -39,7 +39,7 @@ func TestDetectPhase_End(t *testing.T) {
 		},
 	}
 
-	if got := DetectPhase(game); got != PhaseEnd {
+	if got := DetectPhase(game.View(0)); got != PhaseEnd {
 		t.Fatalf("DetectPhase = %v, want %v", got, PhaseEnd)
 	}
 }
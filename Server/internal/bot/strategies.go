@@ -49,15 +49,36 @@ func (b *StandardBot) OnEvent(event interface{}, isRecipient bool) {
 	}
 }
 
-func (b *StandardBot) CalculateMove(game *domain.Game, player *domain.Player) (Move, error) {
+func (b *StandardBot) CalculateMove(view domain.PlayerView) (Move, error) {
 	// 1. Identify Context
-	if player == nil || len(player.Hand) == 0 {
+	if len(view.Hand) == 0 {
 		return Move{Pass: true}, nil
 	}
 
+	ranked, passScore := b.rankMoves(view)
+	if len(ranked) == 0 {
+		return Move{Pass: true}, nil
+	}
+
+	if view.LastPlayedCombination.Type != domain.Invalid && ranked[0].Score < passScore {
+		return Move{Pass: true}, nil
+	}
+
+	return Move{Cards: ranked[0].Move.Cards}, nil
+}
+
+// rankedMove is a scored move plus short, human-readable reasons for its biggest score adjustments.
+type rankedMove struct {
+	internal.ScoredMove
+	Reasons []string
+}
+
+// rankMoves scores every legal move for the viewer, best first. When responding, a move should only be
+// played if it scores at least passScore (the value of keeping the hand as it is).
+func (b *StandardBot) rankMoves(view domain.PlayerView) ([]rankedMove, float64) {
 	// Sync Memory with current hand
 	if b.Memory != nil {
-		b.Memory.UpdateHand(player.Hand)
+		b.Memory.UpdateHand(view.Hand)
 	}
 	if b.Estimator == nil && b.Memory != nil {
 		b.Estimator = brain.NewEstimator(b.Memory)
@@ -70,7 +91,7 @@ func (b *StandardBot) CalculateMove(game *domain.Game, player *domain.Player) (M
 	}
 
 	// Tactical Hand Organization (Selector Pipeline)
-	options := internal.GetTacticalOptions(player.Hand)
+	options := internal.GetTacticalOptions(view.Hand)
 	
 	// Pipeline Execution
 	ctx := &SelectionContext{
@@ -85,100 +106,105 @@ func (b *StandardBot) CalculateMove(game *domain.Game, player *domain.Player) (M
 	// The winner determines our "Plan"
 	organized := ctx.CurrentBest
 
-	// 2. Generate all valid moves
-	lastCombo := game.LastPlayedCombination
-	validMoves := internal.GetValidMoves(player.Hand, lastCombo)
+	// 2. Generate all valid moves (the view applies the rule set and the opening card)
+	lastCombo := view.LastPlayedCombination
+	rules := view.RuleSet()
+	validMoves := internal.ValidMovesFrom(view.Hand, view.LegalMoves())
 
 	if len(validMoves) == 0 {
-		return Move{Pass: true}, nil
+		return nil, 0
 	}
 
 	// 3. Phase-aware scoring with pass logic.
-	phase := internal.DetectPhase(game)
+	phase := internal.DetectPhase(view)
 	weights := DefaultTuning.ForPhase(phase)
 
 	// Deterministic threat detection
 	threat := false
-	if game != nil && DefaultTuning.ThreatThreshold > 0 {
-		for i := 0; i < 4; i++ {
-			if i == player.Seat {
+	if DefaultTuning.ThreatThreshold > 0 {
+		for _, opponent := range view.Seats {
+			if opponent.Seat == view.Seat {
 				continue
 			}
-			var opponent *domain.Player
-			for _, p := range game.Players {
-				if p.Seat == i {
-					opponent = p
-					break
-				}
-			}
-			if opponent != nil && !opponent.Finished && len(opponent.Hand) > 0 && len(opponent.Hand) <= DefaultTuning.ThreatThreshold {
+			if !opponent.Finished && opponent.CardsRemaining > 0 && opponent.CardsRemaining <= DefaultTuning.ThreatThreshold {
 				threat = true
 				break
 			}
 		}
 	}
 
-	scored := internal.BuildScoredMoves(player.Hand, validMoves, weights, threat)
+	scored := internal.BuildScoredMoves(view.Hand, validMoves, weights, threat)
+	ranked := make([]rankedMove, len(scored))
 
 	// 4. Apply State-Aware reasoning (Boss Bonus / Lead Chance / Opponent Safety / Tactical Protection)
 	for i := range scored {
+		m := &ranked[i]
+		m.ScoredMove = scored[i]
+		if len(m.Remaining) == 0 {
+			m.Reasons = append(m.Reasons, ReasonFinishesHand)
+		}
+
 		// Penalty for breaking tactical structures
 		// We calculate penalty against the CHOSEN organization strategy.
-		if isBreakingBomb(scored[i].Move.Cards, organized.Bombs) {
-			scored[i].Score -= 1000.0 // Protect Nukes
+		if isBreakingBomb(m.Move.Cards, organized.Bombs) {
+			m.Score -= 1000.0 // Protect Nukes
+			m.Reasons = append(m.Reasons, ReasonBreaksBomb)
 		}
-		if isBreakingStraight(scored[i].Move.Cards, organized.Straights) {
-			scored[i].Score -= 50.0 // Protect fragile straights
+		if isBreakingStraight(m.Move.Cards, organized.Straights) {
+			m.Score -= 50.0 // Protect fragile straights
+			m.Reasons = append(m.Reasons, ReasonBreaksStraight)
 		}
 
 		if b.Estimator != nil {
 			// Bonus for Boss cards
-			if len(scored[i].Move.Cards) == 1 && b.Memory.IsBoss(scored[i].Move.Cards[0]) {
-				scored[i].Score += 50.0 // Significant boost for unbeatable singles
+			if len(m.Move.Cards) == 1 && b.Memory.IsBoss(m.Move.Cards[0]) {
+				m.Score += 50.0 // Significant boost for unbeatable singles
+				m.Reasons = append(m.Reasons, ReasonUnbeatable)
 			}
 
 			// Adjust score based on lead-turning probability for singles
-			if len(scored[i].Move.Cards) == 1 {
-				prob := b.Estimator.LeadTurnProbability(scored[i].Move.Cards[0])
-				scored[i].Score += prob * 10.0
+			if len(m.Move.Cards) == 1 {
+				prob := b.Estimator.LeadTurnProbability(m.Move.Cards[0])
+				m.Score += prob * 10.0
 			}
 
 			// Opponent Modeling: Is this move safe from next players?
-			safety := b.Estimator.IsSafeFromNextPlayers(scored[i].Combo, player.Seat)
-			scored[i].Score += safety * 25.0 // Reward safe plays
+			safety := b.Estimator.IsSafeFromNextPlayers(m.Combo, view.Seat)
+			m.Score += safety * 25.0 // Reward safe plays
+			if safety > 0 {
+				m.Reasons = append(m.Reasons, ReasonOpponentsPassed)
+			}
 
 			// Dominance: Are we capitalizing on a known ceiling?
-			dominance := b.Estimator.GetDominanceScore(scored[i].Combo, player.Seat)
-			scored[i].Score += dominance * 30.0
+			dominance := b.Estimator.GetDominanceScore(m.Combo, view.Seat)
+			m.Score += dominance * 30.0
+			if dominance > 0 {
+				m.Reasons = append(m.Reasons, ReasonUnderCeiling)
+			}
 
 			// Strategic Leading: Favor types the NEXT player is likely exhausted of
-			nextSeat := (player.Seat + 1) % 4
-			likelihood := b.Estimator.GetComboLikelihood(nextSeat, scored[i].Combo.Type)
-			scored[i].Score += (1.0 - likelihood) * 10.0 // Reward "blocking" plays
+			nextSeat := (view.Seat + 1) % 4
+			likelihood := b.Estimator.GetComboLikelihood(nextSeat, m.Combo.Type)
+			m.Score += (1.0 - likelihood) * 10.0 // Reward "blocking" plays
 		}
 
 		// Reward Chopping High Value Targets (Pigs/Bombs)
-		// We use domain.DetectChop to see if this move qualifies as a special capture.
-		if isChop, _ := domain.DetectChop(lastCombo.Cards, scored[i].Move.Cards); isChop {
-			scored[i].Score += 500.0 // Massive priority to chop pigs/bombs
+		// We use the rule set's DetectChop to see if this move qualifies as a special capture.
+		if isChop, _ := rules.DetectChop(lastCombo.Cards, m.Move.Cards); isChop {
+			m.Score += 500.0 // Massive priority to chop pigs/bombs
+			m.Reasons = append(m.Reasons, ReasonChops)
 		}
 	}
 
-	sort.Slice(scored, func(i, j int) bool {
-		if scored[i].Score != scored[j].Score {
-			return scored[i].Score > scored[j].Score
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
 		}
-		return scored[i].Combo.Value < scored[j].Combo.Value
+		return ranked[i].Combo.Value < ranked[j].Combo.Value
 	})
 
-	if lastCombo.Type != domain.Invalid {
-		currentScore := internal.ScoreHand(player.Hand, weights)
-		if scored[0].Score < currentScore+DefaultTuning.PassThreshold {
-			return Move{Pass: true}, nil
-		}
-	}
-
-	return Move{Cards: scored[0].Move.Cards}, nil
+	passScore := internal.ScoreHand(view.Hand, weights) + DefaultTuning.PassThreshold
+	return ranked, passScore
 }
 
 func isBreakingBomb(moveCards []domain.Card, bombs []domain.CardCombination) bool {
@@ -225,15 +251,8 @@ func (b *StandardBot) DumpState() string {
 		return "Brain not initialized"
 	}
 	
-	playedCount := 0
-	mineCount := 0
-	for _, status := range b.Memory.DeckStatus {
-		if status == brain.StatusPlayed {
-			playedCount++
-		} else if status == brain.StatusMine {
-			mineCount++
-		}
-	}
+	playedCount := b.Memory.Played.Len()
+	mineCount := b.Memory.Mine.Len()
 	
 	return "State: Played=" + string(rune('0'+playedCount/10)) + string(rune('0'+playedCount%10)) + 
 		   ", Hand=" + string(rune('0'+mineCount/10)) + string(rune('0'+mineCount%10))
@@ -33,7 +33,7 @@ func TestStandardBot_ProtectsBomb(t *testing.T) {
 	}
 	
 	bot := &StandardBot{Memory: brain.NewMemory()}
-	move, err := bot.CalculateMove(game, player)
+	move, err := bot.CalculateMove(game.View(player.Seat))
 	if err != nil {
 		t.Fatalf("CalculateMove failed: %v", err)
 	}
@@ -72,7 +72,7 @@ func TestStandardBot_RefusesToBreakBomb(t *testing.T) {
 	}
 	
 	bot := &StandardBot{Memory: brain.NewMemory()}
-	move, err := bot.CalculateMove(game, player)
+	move, err := bot.CalculateMove(game.View(player.Seat))
 	if err != nil {
 		t.Fatalf("CalculateMove failed: %v", err)
 	}
@@ -109,7 +109,7 @@ func TestStandardBot_PlaysBossOnFreeTurn(t *testing.T) {
 	}
 	
 	bot := &StandardBot{Memory: mem}
-	move, _ := bot.CalculateMove(game, player)
+	move, _ := bot.CalculateMove(game.View(player.Seat))
 	
 	if len(move.Cards) == 1 && move.Cards[0].Rank == 12 && move.Cards[0].Suit == 3 {
 		t.Log("Bot played Boss 2H. Confirming behavior.")
@@ -148,7 +148,7 @@ func TestStandardBot_Pipeline_PairsPreference(t *testing.T) {
 		},
 	}
 	
-	move, err := bot.CalculateMove(game, player)
+	move, err := bot.CalculateMove(game.View(player.Seat))
 	if err != nil { t.Fatalf("CalculateMove failed: %v", err) }
 	
 	if move.Pass {
@@ -189,7 +189,7 @@ func TestStandardBot_Flexibility_PairsVsStraight(t *testing.T) {
 		},
 	}
 	
-	move, err := bot.CalculateMove(game, player)
+	move, err := bot.CalculateMove(game.View(player.Seat))
 	if err != nil { t.Fatalf("CalculateMove failed: %v", err) }
 	
 	if move.Pass {
@@ -216,7 +216,7 @@ func TestStandardBot_Flexibility_PairsVsStraight(t *testing.T) {
 		},
 	}
 	
-	move2, _ := bot.CalculateMove(game2, player)
+	move2, _ := bot.CalculateMove(game2.View(player.Seat))
 	if move2.Pass {
 		t.Error("Bot passed on Single 5 when it had Single 6!")
 	} else {
@@ -39,7 +39,7 @@ func TestStandardBot_PreservesStraight(t *testing.T) {
 	}
 	
 	bot := &StandardBot{Memory: brain.NewMemory()}
-	move, err := bot.CalculateMove(game, player)
+	move, err := bot.CalculateMove(game.View(player.Seat))
 	if err != nil {
 		t.Fatalf("CalculateMove failed: %v", err)
 	}
@@ -73,7 +73,7 @@ func TestStandardBot_FinishesGame(t *testing.T) {
 	}
 	
 	bot := &StandardBot{Memory: brain.NewMemory()}
-	move, _ := bot.CalculateMove(game, player)
+	move, _ := bot.CalculateMove(game.View(player.Seat))
 	
 	if len(move.Cards) != 1 || move.Cards[0].Rank != 12 {
 		t.Errorf("Bot should have played its last card to win, got %+v", move.Cards)
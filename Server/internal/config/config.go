@@ -2,16 +2,102 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 )
 
 type BetTier struct {
 	ID      string `json:"id"`
 	BaseBet int64  `json:"base_bet"`
+	// SettlementPolicy names an entry of GameConfig.SettlementPolicies; empty means the standard rank matrix.
+	SettlementPolicy string `json:"settlement_policy"`
+	// MinBalance is the gold a player needs to sit at the tier; 0 means no minimum.
+	MinBalance int64 `json:"min_balance"`
+	// MaxBalance keeps richer players out of the tier; 0 means no maximum.
+	MaxBalance int64 `json:"max_balance"`
 }
 
+// Admits reports whether a player with balance may sit at the tier.
+func (t BetTier) Admits(balance int64) bool {
+	return balance >= t.MinBalance && (t.MaxBalance == 0 || balance <= t.MaxBalance)
+}
+
+// Settlement policy types (see the domain SettlementPolicy implementations).
+const (
+	SettlementRankMatrix     = "rank_matrix"
+	SettlementWinnerTakesAll = "winner_takes_all"
+	SettlementCardCount      = "card_count"
+)
+
+// SettlementPolicyConfig describes a named settlement policy.
+type SettlementPolicyConfig struct {
+	Type string `json:"type"` // One of the Settlement* constants
+	// RankMatrix maps player count to BaseBet multipliers per finishing rank (rank_matrix only).
+	// Rows for 2, 3 and 4 players are required and each must sum to zero.
+	RankMatrix map[int][]int64 `json:"rank_matrix"`
+	// LoserMultiplier is the BaseBet multiple each loser pays (winner_takes_all only, default 1).
+	LoserMultiplier int64 `json:"loser_multiplier"`
+	// CardMultiplier is the BaseBet multiple paid per card left in hand (card_count only, default 1).
+	CardMultiplier int64 `json:"card_multiplier"`
+	// TaxRate overrides GameConfig.TaxRate for tables using this policy.
+	TaxRate *float64 `json:"tax_rate"`
+}
+
+// LeftoverPenaltyConfig holds BaseBet multipliers charged for cards left in a loser's hand ("thoi heo / thoi bom").
+type LeftoverPenaltyConfig struct {
+	BlackPig  int64 `json:"black_pig"`
+	RedPig    int64 `json:"red_pig"`
+	Quad      int64 `json:"quad"`
+	ThreePine int64 `json:"three_pine"`
+	FourPine  int64 `json:"four_pine"`
+	FivePine  int64 `json:"five_pine"`
+}
+
+// ChopPenaltyTable holds the BaseBet multiplier paid by the owner of a chopped combination.
+// Every entry is required; pairs of 2s are priced by colour composition.
+type ChopPenaltyTable struct {
+	BlackPig        int64 `json:"black_pig"`
+	RedPig          int64 `json:"red_pig"`
+	BlackPairOfTwos int64 `json:"black_pair_of_twos"` // Spades + Clubs
+	MixedPairOfTwos int64 `json:"mixed_pair_of_twos"` // One black, one red
+	RedPairOfTwos   int64 `json:"red_pair_of_twos"`   // Diamonds + Hearts
+	ThreePine       int64 `json:"three_pine"`
+	Quad            int64 `json:"quad"`
+	FourPine        int64 `json:"four_pine"`
+	FivePine        int64 `json:"five_pine"`
+}
+
+// ChopPenaltyConfig is the default chop table plus full-table overrides.
+// A match-type override takes precedence over a tier override.
+type ChopPenaltyConfig struct {
+	Default    ChopPenaltyTable            `json:"default"`
+	Tiers      map[string]ChopPenaltyTable `json:"tiers"`       // Keyed by BetTier.ID
+	MatchTypes map[string]ChopPenaltyTable `json:"match_types"` // Keyed by match type name, e.g. "ranked"
+}
+
+// HintSettings controls the REQUEST_HINT opcode for one match type.
+type HintSettings struct {
+	Enabled         bool `json:"enabled"`
+	CooldownSeconds int  `json:"cooldown_seconds"` // Minimum seconds between two hints for the same player
+	MaxPerGame      int  `json:"max_per_game"`     // Hints each player may request per game; 0 means unlimited
+	Suggestions     int  `json:"suggestions"`      // Moves returned per hint
+}
+
+// DefaultHintSettings apply when no hint configuration is loaded.
+var DefaultHintSettings = HintSettings{Enabled: true, CooldownSeconds: 5, Suggestions: 3}
+
+// HintConfig is the default hint settings plus per match type overrides.
+type HintConfig struct {
+	Default    HintSettings            `json:"default"`
+	MatchTypes map[string]HintSettings `json:"match_types"` // Keyed by match type name, e.g. "ranked"
+}
+
+// MatchTypeNames lists the match type keys accepted in overrides (see MatchType in tienlen.proto).
+var MatchTypeNames = []string{"casual", "vip", "ranked"}
+
 type GameConfig struct {
 	TaxRate             float64   `json:"tax_rate"`
 	DefaultTier         string    `json:"default_tier"`
@@ -21,6 +107,25 @@ type GameConfig struct {
 	BotAutoFillDelaySeconds int `json:"bot_auto_fill_delay_seconds"`
 	// MinPlayersToStartGame defines the minimum number of occupied seats required to start a game.
 	MinPlayersToStartGame int `json:"min_players_to_start_game"`
+	// StartCountdownSeconds is how long the lobby counts down once enough players are ready before starting on its own.
+	StartCountdownSeconds int `json:"start_countdown_seconds"`
+	// RematchVoteSeconds is how long players have after a game to vote for a rematch before their seat is released.
+	RematchVoteSeconds int `json:"rematch_vote_seconds"`
+	// ReconnectGraceSeconds is how long a disconnected player's seat is held for them to rejoin.
+	// Seats in a running game are held until it ends regardless.
+	ReconnectGraceSeconds int `json:"reconnect_grace_seconds"`
+	// InstantWinMultiplier is the BaseBet multiplier each loser pays when a dealt hand wins instantly ("toi trang").
+	InstantWinMultiplier int64 `json:"instant_win_multiplier"`
+	// CongMultiplier is the minimum BaseBet multiplier paid by a player who never played a card ("cong").
+	CongMultiplier int64 `json:"cong_multiplier"`
+	// SettlementPolicies are named payout policies referenced by BetTier.SettlementPolicy.
+	SettlementPolicies map[string]SettlementPolicyConfig `json:"settlement_policies"`
+	// ChopPenalties prices chopped 2s and bombs. Required.
+	ChopPenalties *ChopPenaltyConfig `json:"chop_penalties"`
+	// LeftoverPenalties overrides the default end-of-game penalties for leftover 2s and bombs.
+	LeftoverPenalties *LeftoverPenaltyConfig `json:"leftover_penalties"`
+	// Hints configures move hints for human players; nil means DefaultHintSettings everywhere.
+	Hints *HintConfig `json:"hints"`
 }
 
 var (
@@ -38,16 +143,178 @@ func LoadGameConfig(path string) error {
 			return
 		}
 
-		var c GameConfig
-		if err := json.Unmarshal(data, &c); err != nil {
-			loadErr = fmt.Errorf("failed to unmarshal game config: %w", err)
+		c, err := parseGameConfig(data)
+		if err != nil {
+			loadErr = err
 			return
 		}
-		cfg = &c
+		cfg = c
 	})
 	return loadErr
 }
 
+// parseGameConfig decodes and validates a game configuration.
+func parseGameConfig(data []byte) (*GameConfig, error) {
+	var c GameConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game config: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid game config: %w", err)
+	}
+	return &c, nil
+}
+
+// Validate checks that the configuration is complete.
+func (c *GameConfig) Validate() error {
+	for name, policy := range c.SettlementPolicies {
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("settlement_policies.%s: %w", name, err)
+		}
+	}
+	for _, tier := range c.Tiers {
+		if tier.MinBalance < 0 || tier.MaxBalance < 0 || (tier.MaxBalance != 0 && tier.MaxBalance < tier.MinBalance) {
+			return fmt.Errorf("tier %q: invalid balance range [%d, %d]", tier.ID, tier.MinBalance, tier.MaxBalance)
+		}
+		if tier.SettlementPolicy == "" {
+			continue
+		}
+		if _, ok := c.SettlementPolicies[tier.SettlementPolicy]; !ok {
+			return fmt.Errorf("tier %q: unknown settlement policy %q", tier.ID, tier.SettlementPolicy)
+		}
+	}
+
+	if c.ChopPenalties == nil {
+		return errors.New("chop_penalties is required")
+	}
+	if err := c.ChopPenalties.Default.validate(); err != nil {
+		return fmt.Errorf("chop_penalties.default: %w", err)
+	}
+	for tierID, table := range c.ChopPenalties.Tiers {
+		if !c.hasTier(tierID) {
+			return fmt.Errorf("chop_penalties.tiers: unknown tier %q", tierID)
+		}
+		if err := table.validate(); err != nil {
+			return fmt.Errorf("chop_penalties.tiers.%s: %w", tierID, err)
+		}
+	}
+	for matchType, table := range c.ChopPenalties.MatchTypes {
+		if !isMatchTypeName(matchType) {
+			return fmt.Errorf("chop_penalties.match_types: unknown match type %q", matchType)
+		}
+		if err := table.validate(); err != nil {
+			return fmt.Errorf("chop_penalties.match_types.%s: %w", matchType, err)
+		}
+	}
+
+	if c.Hints != nil {
+		if err := c.Hints.Default.validate(); err != nil {
+			return fmt.Errorf("hints.default: %w", err)
+		}
+		for matchType, settings := range c.Hints.MatchTypes {
+			if !isMatchTypeName(matchType) {
+				return fmt.Errorf("hints.match_types: unknown match type %q", matchType)
+			}
+			if err := settings.validate(); err != nil {
+				return fmt.Errorf("hints.match_types.%s: %w", matchType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validate rejects negative limits and enabled hints that return no suggestions.
+func (h HintSettings) validate() error {
+	if h.CooldownSeconds < 0 || h.MaxPerGame < 0 || h.Suggestions < 0 {
+		return errors.New("cooldown_seconds, max_per_game and suggestions must not be negative")
+	}
+	if h.Enabled && h.Suggestions == 0 {
+		return errors.New("suggestions must be positive when hints are enabled")
+	}
+	return nil
+}
+
+// validate rejects tables with missing (zero) or negative entries.
+func (t ChopPenaltyTable) validate() error {
+	entries := []struct {
+		name  string
+		value int64
+	}{
+		{"black_pig", t.BlackPig},
+		{"red_pig", t.RedPig},
+		{"black_pair_of_twos", t.BlackPairOfTwos},
+		{"mixed_pair_of_twos", t.MixedPairOfTwos},
+		{"red_pair_of_twos", t.RedPairOfTwos},
+		{"three_pine", t.ThreePine},
+		{"quad", t.Quad},
+		{"four_pine", t.FourPine},
+		{"five_pine", t.FivePine},
+	}
+
+	var missing []string
+	for _, e := range entries {
+		if e.value <= 0 {
+			missing = append(missing, e.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing or non-positive entries: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// validate checks the fields required by the policy type.
+func (p SettlementPolicyConfig) validate() error {
+	if p.TaxRate != nil && (*p.TaxRate < 0 || *p.TaxRate >= 1) {
+		return fmt.Errorf("tax_rate %v out of range [0, 1)", *p.TaxRate)
+	}
+
+	switch p.Type {
+	case SettlementRankMatrix:
+		for players := 2; players <= 4; players++ {
+			row, ok := p.RankMatrix[players]
+			if !ok {
+				return fmt.Errorf("rank_matrix: missing row for %d players", players)
+			}
+			if len(row) != players {
+				return fmt.Errorf("rank_matrix: row for %d players has %d entries", players, len(row))
+			}
+			var sum int64
+			for _, m := range row {
+				sum += m
+			}
+			if sum != 0 {
+				return fmt.Errorf("rank_matrix: row for %d players sums to %d, want 0", players, sum)
+			}
+		}
+	case SettlementWinnerTakesAll:
+		if p.LoserMultiplier < 0 {
+			return errors.New("loser_multiplier must not be negative")
+		}
+	case SettlementCardCount:
+		if p.CardMultiplier < 0 {
+			return errors.New("card_multiplier must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", p.Type)
+	}
+	return nil
+}
+
+func (c *GameConfig) hasTier(tierID string) bool {
+	_, ok := c.betTier(tierID)
+	return ok
+}
+
+func isMatchTypeName(name string) bool {
+	for _, n := range MatchTypeNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetGameConfig returns the global game configuration.
 func GetGameConfig() *GameConfig {
 	return cfg
@@ -79,3 +346,117 @@ func GetBaseBet(tierID string) int64 {
 
 	return 100
 }
+
+// HasTier reports whether tierID names a configured bet tier.
+func HasTier(tierID string) bool {
+	return cfg != nil && cfg.hasTier(tierID)
+}
+
+// GetBetTier returns the tier with the given ID (empty means the default tier).
+// The second result is false when no configuration is loaded or the tier does not exist.
+func GetBetTier(tierID string) (BetTier, bool) {
+	if cfg == nil {
+		return BetTier{}, false
+	}
+	return cfg.betTier(ResolveTierID(tierID))
+}
+
+func (c *GameConfig) betTier(tierID string) (BetTier, bool) {
+	for _, tier := range c.Tiers {
+		if tier.ID == tierID {
+			return tier, true
+		}
+	}
+	return BetTier{}, false
+}
+
+// HighestAffordableTier returns the tier with the largest base bet that admits balance.
+// The second result is false when no configuration is loaded or no tier admits balance.
+func HighestAffordableTier(balance int64) (BetTier, bool) {
+	if cfg == nil {
+		return BetTier{}, false
+	}
+	return cfg.highestAffordableTier(balance)
+}
+
+func (c *GameConfig) highestAffordableTier(balance int64) (BetTier, bool) {
+	var best BetTier
+	found := false
+	for _, tier := range c.Tiers {
+		if tier.Admits(balance) && (!found || tier.BaseBet > best.BaseBet) {
+			best, found = tier, true
+		}
+	}
+	return best, found
+}
+
+// ResolveTierID returns tierID, or the default tier's ID when tierID is empty.
+func ResolveTierID(tierID string) string {
+	if tierID == "" && cfg != nil {
+		return cfg.DefaultTier
+	}
+	return tierID
+}
+
+// GetChopPenalties returns the chop table for a tier and match type name.
+// Lookup order: match type override, tier override (empty tierID means the default tier), default table.
+// The second result is false when no configuration is loaded.
+func GetChopPenalties(tierID, matchType string) (ChopPenaltyTable, bool) {
+	if cfg == nil || cfg.ChopPenalties == nil {
+		return ChopPenaltyTable{}, false
+	}
+	return cfg.ChopPenalties.lookup(tierID, matchType, cfg.DefaultTier), true
+}
+
+func (c *ChopPenaltyConfig) lookup(tierID, matchType, defaultTier string) ChopPenaltyTable {
+	if table, ok := c.MatchTypes[matchType]; ok {
+		return table
+	}
+	if tierID == "" {
+		tierID = defaultTier
+	}
+	if table, ok := c.Tiers[tierID]; ok {
+		return table
+	}
+	return c.Default
+}
+
+// GetSettlementPolicy returns the settlement policy of a tier (empty tierID means the default tier),
+// with TaxRate resolved from GameConfig.TaxRate when the policy does not override it.
+// The second result is false when no configuration is loaded or the tier uses the standard rank matrix.
+func GetSettlementPolicy(tierID string) (SettlementPolicyConfig, bool) {
+	if cfg == nil {
+		return SettlementPolicyConfig{}, false
+	}
+	if tierID == "" {
+		tierID = cfg.DefaultTier
+	}
+
+	for _, tier := range cfg.Tiers {
+		if tier.ID != tierID || tier.SettlementPolicy == "" {
+			continue
+		}
+		policy, ok := cfg.SettlementPolicies[tier.SettlementPolicy]
+		if !ok {
+			return SettlementPolicyConfig{}, false
+		}
+		if policy.TaxRate == nil {
+			rate := cfg.TaxRate
+			policy.TaxRate = &rate
+		}
+		return policy, true
+	}
+	return SettlementPolicyConfig{}, false
+}
+
+// GetHintSettings returns the hint settings for a match type name, falling back to the default
+// settings and then to DefaultHintSettings when no hint configuration is loaded.
+func GetHintSettings(matchType string) HintSettings {
+	if cfg == nil || cfg.Hints == nil {
+		return DefaultHintSettings
+	}
+	if settings, ok := cfg.Hints.MatchTypes[matchType]; ok {
+		return settings
+	}
+	return cfg.Hints.Default
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const validTable = `{"black_pig": 1, "red_pig": 2, "black_pair_of_twos": 2, "mixed_pair_of_twos": 3,
+	"red_pair_of_twos": 4, "three_pine": 3, "quad": 4, "four_pine": 5, "five_pine": 10}`
+
+func TestParseGameConfig_ChopPenalties(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name: "Valid with overrides",
+			json: `{"default_tier": "casual", "tiers": [{"id": "casual", "base_bet": 100}],
+				"chop_penalties": {"default": ` + validTable + `,
+				"tiers": {"casual": ` + validTable + `}, "match_types": {"ranked": ` + validTable + `}}}`,
+		},
+		{
+			name:    "Missing table",
+			json:    `{"default_tier": "casual"}`,
+			wantErr: "chop_penalties is required",
+		},
+		{
+			name:    "Missing default entries",
+			json:    `{"chop_penalties": {"default": {"black_pig": 1, "red_pig": 2}}}`,
+			wantErr: "black_pair_of_twos",
+		},
+		{
+			name: "Incomplete tier override",
+			json: `{"tiers": [{"id": "casual", "base_bet": 100}],
+				"chop_penalties": {"default": ` + validTable + `, "tiers": {"casual": {"quad": 8}}}}`,
+			wantErr: "chop_penalties.tiers.casual",
+		},
+		{
+			name:    "Unknown tier override",
+			json:    `{"chop_penalties": {"default": ` + validTable + `, "tiers": {"gold": ` + validTable + `}}}`,
+			wantErr: `unknown tier "gold"`,
+		},
+		{
+			name:    "Unknown match type override",
+			json:    `{"chop_penalties": {"default": ` + validTable + `, "match_types": {"arcade": ` + validTable + `}}}`,
+			wantErr: `unknown match type "arcade"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGameConfig([]byte(tt.json))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseGameConfig_SettlementPolicies(t *testing.T) {
+	chop := `"chop_penalties": {"default": ` + validTable + `}`
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name: "Valid policies",
+			json: `{"tiers": [{"id": "casual", "base_bet": 100, "settlement_policy": "wta"}],
+				"settlement_policies": {
+					"wta": {"type": "winner_takes_all", "tax_rate": 0.1},
+					"last": {"type": "rank_matrix", "rank_matrix": {"2": [1, -1], "3": [1, 0, -1], "4": [1, 0, 0, -1]}},
+					"cards": {"type": "card_count", "card_multiplier": 2}
+				}, ` + chop + `}`,
+		},
+		{
+			name:    "Unknown policy on tier",
+			json:    `{"tiers": [{"id": "casual", "base_bet": 100, "settlement_policy": "nope"}], ` + chop + `}`,
+			wantErr: `unknown settlement policy "nope"`,
+		},
+		{
+			name:    "Unknown policy type",
+			json:    `{"settlement_policies": {"x": {"type": "lottery"}}, ` + chop + `}`,
+			wantErr: `unknown type "lottery"`,
+		},
+		{
+			name:    "Missing matrix row",
+			json:    `{"settlement_policies": {"x": {"type": "rank_matrix", "rank_matrix": {"2": [1, -1]}}}, ` + chop + `}`,
+			wantErr: "missing row for 3 players",
+		},
+		{
+			name:    "Matrix row not zero-sum",
+			json:    `{"settlement_policies": {"x": {"type": "rank_matrix", "rank_matrix": {"2": [1, -1], "3": [2, -1, -2], "4": [2, 1, -1, -2]}}}, ` + chop + `}`,
+			wantErr: "sums to -1",
+		},
+		{
+			name:    "Tax out of range",
+			json:    `{"settlement_policies": {"x": {"type": "card_count", "tax_rate": 1.5}}, ` + chop + `}`,
+			wantErr: "tax_rate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGameConfig([]byte(tt.json))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChopPenaltyConfig_Lookup(t *testing.T) {
+	c := &ChopPenaltyConfig{
+		Default:    ChopPenaltyTable{Quad: 4},
+		Tiers:      map[string]ChopPenaltyTable{"high_roller": {Quad: 6}},
+		MatchTypes: map[string]ChopPenaltyTable{"ranked": {Quad: 8}},
+	}
+
+	tests := []struct {
+		tier, matchType string
+		want            int64
+	}{
+		{"casual", "casual", 4},
+		{"high_roller", "casual", 6},
+		{"high_roller", "ranked", 8},
+		{"", "casual", 6}, // Empty tier resolves to the default tier
+	}
+	for _, tt := range tests {
+		if got := c.lookup(tt.tier, tt.matchType, "high_roller").Quad; got != tt.want {
+			t.Errorf("lookup(%q, %q).Quad = %d, want %d", tt.tier, tt.matchType, got, tt.want)
+		}
+	}
+}
+
+func TestHighestAffordableTier(t *testing.T) {
+	c := &GameConfig{Tiers: []BetTier{
+		{ID: "casual", BaseBet: 100, MinBalance: 1000, MaxBalance: 50000},
+		{ID: "high_roller", BaseBet: 10000, MinBalance: 100000},
+		{ID: "ranked", BaseBet: 1000, MinBalance: 10000},
+	}}
+
+	tests := []struct {
+		balance int64
+		want    string
+	}{
+		{500, ""},
+		{1000, "casual"},
+		{20000, "ranked"},
+		{100000, "high_roller"},
+	}
+	for _, tt := range tests {
+		tier, ok := c.highestAffordableTier(tt.balance)
+		if tier.ID != tt.want || ok != (tt.want != "") {
+			t.Errorf("highestAffordableTier(%d) = %q, %v; want %q", tt.balance, tier.ID, ok, tt.want)
+		}
+	}
+
+	if _, err := parseGameConfig([]byte(`{"tiers": [{"id": "casual", "base_bet": 100, "min_balance": 1000, "max_balance": 500}],
+		"chop_penalties": {"default": ` + validTable + `}}`)); err == nil || !strings.Contains(err.Error(), "balance range") {
+		t.Errorf("error = %v, want an invalid balance range", err)
+	}
+}
+
+func TestShippedGameConfigIsValid(t *testing.T) {
+	data, err := os.ReadFile("../../data/game_config.json")
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if _, err := parseGameConfig(data); err != nil {
+		t.Fatalf("shipped config rejected: %v", err)
+	}
+}
+
+func TestParseGameConfig_Hints(t *testing.T) {
+	base := `{"chop_penalties": {"default": ` + validTable + `}, "hints": `
+	tests := []struct {
+		name    string
+		hints   string
+		wantErr string
+	}{
+		{
+			name:  "Valid with ranked disabled",
+			hints: `{"default": {"enabled": true, "suggestions": 3}, "match_types": {"ranked": {"enabled": false}}}`,
+		},
+		{
+			name:    "Enabled without suggestions",
+			hints:   `{"default": {"enabled": true}}`,
+			wantErr: "hints.default",
+		},
+		{
+			name:    "Negative cooldown",
+			hints:   `{"default": {"enabled": false, "cooldown_seconds": -1}}`,
+			wantErr: "must not be negative",
+		},
+		{
+			name:    "Unknown match type",
+			hints:   `{"default": {"enabled": false}, "match_types": {"arcade": {"enabled": false}}}`,
+			wantErr: `unknown match type "arcade"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGameConfig([]byte(base + tt.hints + "}"))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetHintSettings(t *testing.T) {
+	prev := cfg
+	defer func() { cfg = prev }()
+
+	cfg = nil
+	if got := GetHintSettings("ranked"); got != DefaultHintSettings {
+		t.Errorf("without config got %+v, want defaults", got)
+	}
+
+	cfg = &GameConfig{Hints: &HintConfig{
+		Default:    HintSettings{Enabled: true, Suggestions: 2},
+		MatchTypes: map[string]HintSettings{"ranked": {}},
+	}}
+	if got := GetHintSettings("casual"); !got.Enabled || got.Suggestions != 2 {
+		t.Errorf("casual got %+v, want the default settings", got)
+	}
+	if got := GetHintSettings("ranked"); got.Enabled {
+		t.Errorf("ranked got %+v, want hints disabled", got)
+	}
+}
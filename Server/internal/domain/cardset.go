@@ -0,0 +1,193 @@
+package domain
+
+import "math/bits"
+
+// CardSet is a set of cards packed into a bitmask: bit CardPower(c) is set when c is in the set.
+// Rank r occupies bits 4r..4r+3 (one per suit), so rank and suit queries are shifts and masks
+// rather than map or slice walks.
+type CardSet uint64
+
+const (
+	// FullDeck holds all 52 cards.
+	FullDeck CardSet = 1<<52 - 1
+
+	rankNibble CardSet = 0xF
+	twosMask   CardSet = rankNibble << (12 * 4)
+	spadesMask CardSet = 0x1111111111111 // Bit 0 of every rank nibble
+)
+
+// NewCardSet builds a set from cards. Cards outside the deck are ignored and duplicates collapse,
+// so callers validating a play should compare Len() with len(cards).
+func NewCardSet(cards []Card) CardSet {
+	var s CardSet
+	for _, c := range cards {
+		s |= cardBit(c)
+	}
+	return s
+}
+
+// cardBit returns the single-card set for c, or 0 when c is not a real card.
+func cardBit(c Card) CardSet {
+	if c.Rank < 0 || c.Rank > 12 || c.Suit < 0 || c.Suit > 3 {
+		return 0
+	}
+	return 1 << uint(CardPower(c))
+}
+
+// cardAt returns the card with the given power.
+func cardAt(power int) Card {
+	return Card{Rank: int32(power / 4), Suit: int32(power % 4)}
+}
+
+// Has reports whether c is in the set.
+func (s CardSet) Has(c Card) bool { return cardBit(c) != 0 && s&cardBit(c) != 0 }
+
+// Add returns the set with c added.
+func (s CardSet) Add(c Card) CardSet { return s | cardBit(c) }
+
+// Remove returns the set with c removed.
+func (s CardSet) Remove(c Card) CardSet { return s &^ cardBit(c) }
+
+// Union returns the cards in either set.
+func (s CardSet) Union(o CardSet) CardSet { return s | o }
+
+// Intersect returns the cards in both sets.
+func (s CardSet) Intersect(o CardSet) CardSet { return s & o }
+
+// Minus returns the cards in s that are not in o.
+func (s CardSet) Minus(o CardSet) CardSet { return s &^ o }
+
+// ContainsAll reports whether every card of o is in s.
+func (s CardSet) ContainsAll(o CardSet) bool { return o&^s == 0 }
+
+// Len returns the number of cards in the set.
+func (s CardSet) Len() int { return bits.OnesCount64(uint64(s)) }
+
+// IsEmpty reports whether the set holds no cards.
+func (s CardSet) IsEmpty() bool { return s == 0 }
+
+// Cards returns the cards in ascending power, the same order as SortHand.
+func (s CardSet) Cards() []Card {
+	cards := make([]Card, 0, s.Len())
+	for rest := uint64(s); rest != 0; rest &= rest - 1 {
+		cards = append(cards, cardAt(bits.TrailingZeros64(rest)))
+	}
+	return cards
+}
+
+// Lowest returns the weakest card in the set. The set must not be empty.
+func (s CardSet) Lowest() Card { return cardAt(bits.TrailingZeros64(uint64(s))) }
+
+// Highest returns the strongest card in the set. The set must not be empty.
+func (s CardSet) Highest() Card { return cardAt(63 - bits.LeadingZeros64(uint64(s))) }
+
+// maxPower returns the power of the strongest card, or -1 for an empty set.
+func (s CardSet) maxPower() int32 {
+	return int32(63 - bits.LeadingZeros64(uint64(s)))
+}
+
+// Above returns the cards in the set that are stronger than c.
+func (s CardSet) Above(c Card) CardSet {
+	return s &^ (1<<uint(CardPower(c)+1) - 1)
+}
+
+// OfRank returns the cards of the given rank (0 = 3 ... 12 = 2).
+func (s CardSet) OfRank(rank int32) CardSet { return s & (rankNibble << uint(rank*4)) }
+
+// OfSuit returns the cards of the given suit (0 = Spades ... 3 = Hearts).
+func (s CardSet) OfSuit(suit int32) CardSet { return s & (spadesMask << uint(suit)) }
+
+// RankCount returns how many cards of the given rank are in the set.
+func (s CardSet) RankCount(rank int32) int { return s.OfRank(rank).Len() }
+
+// RankCounts returns the number of cards held of each rank.
+func (s CardSet) RankCounts() [13]int {
+	var counts [13]int
+	for r := range counts {
+		counts[r] = bits.OnesCount64(uint64(s >> uint(r*4) & rankNibble))
+	}
+	return counts
+}
+
+// SameRank reports whether the set is non-empty and every card shares one rank.
+func (s CardSet) SameRank() bool {
+	return s != 0 && s.OfRank(s.Highest().Rank) == s
+}
+
+// SameSuit reports whether the set is non-empty and every card shares one suit.
+func (s CardSet) SameSuit() bool {
+	return s != 0 && s.OfSuit(s.Lowest().Suit) == s
+}
+
+// IsStraight reports whether the set is a straight: three or more consecutive ranks,
+// one card each, without 2s.
+func (s CardSet) IsStraight() bool {
+	return s.Len() >= 3 && s&twosMask == 0 && s.rankRun(1) > 0
+}
+
+// ConsecutivePairs returns the number of pairs when the set is a run of three or more consecutive
+// pairs without 2s (3 for a 3-Pine, 4 for a 4-Pine...), or 0 otherwise.
+func (s CardSet) ConsecutivePairs() int {
+	if n := s.Len(); n < 6 || n%2 != 0 || s&twosMask != 0 {
+		return 0
+	}
+	return s.rankRun(2)
+}
+
+// IsQuad reports whether the set is all four cards of one rank.
+func (s CardSet) IsQuad() bool {
+	return s.Len() == 4 && s.SameRank()
+}
+
+// CombinationType classifies the set under the standard (Southern) combinations.
+// Quads and consecutive pairs are both reported as Bomb.
+func (s CardSet) CombinationType() CardCombinationType {
+	switch n := s.Len(); {
+	case n == 0:
+		return Invalid
+	case n == 1:
+		return Single
+	case s.SameRank():
+		switch n {
+		case 2:
+			return Pair
+		case 3:
+			return Triple
+		default:
+			return Bomb
+		}
+	case s.IsStraight():
+		return Straight
+	case s.ConsecutivePairs() > 0:
+		return Bomb
+	}
+	return Invalid
+}
+
+// String writes the set in NotationText, weakest card first.
+func (s CardSet) String() string {
+	return FormatCards(s.Cards(), NotationText)
+}
+
+// rankRun returns the number of ranks in the set when they are consecutive and each holds exactly
+// perRank cards, or 0 otherwise.
+func (s CardSet) rankRun(perRank int) int {
+	var ranks uint16
+	for r := 0; r < 13; r++ {
+		switch bits.OnesCount64(uint64(s >> uint(r*4) & rankNibble)) {
+		case 0:
+		case perRank:
+			ranks |= 1 << uint(r)
+		default:
+			return 0
+		}
+	}
+	if ranks == 0 {
+		return 0
+	}
+	run := ranks >> uint(bits.TrailingZeros16(ranks))
+	if run&(run+1) != 0 {
+		return 0 // A gap between ranks
+	}
+	return bits.OnesCount16(ranks)
+}
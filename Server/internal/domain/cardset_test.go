@@ -0,0 +1,149 @@
+package domain
+
+import "testing"
+
+func mustCards(t testing.TB, s string) []Card {
+	t.Helper()
+	cards, err := ParseCards(s)
+	if err != nil {
+		t.Fatalf("ParseCards(%q): %v", s, err)
+	}
+	return cards
+}
+
+func TestCardSetAlgebra(t *testing.T) {
+	a := NewCardSet(mustCards(t, "3S 3H 5D 2H"))
+	b := NewCardSet(mustCards(t, "3H 5D 7C"))
+
+	if got := a.Union(b).String(); got != "3S 3H 5D 7C 2H" {
+		t.Errorf("Union = %q", got)
+	}
+	if got := a.Intersect(b).String(); got != "3H 5D" {
+		t.Errorf("Intersect = %q", got)
+	}
+	if got := a.Minus(b).String(); got != "3S 2H" {
+		t.Errorf("Minus = %q", got)
+	}
+	if !a.ContainsAll(a.Intersect(b)) || a.ContainsAll(b) {
+		t.Errorf("ContainsAll mismatch")
+	}
+	if a.Len() != 4 || !a.Has(Card{Rank: 12, Suit: 3}) || a.Has(Card{Rank: 4, Suit: 1}) {
+		t.Errorf("Len/Has mismatch for %s", a)
+	}
+	if a.Lowest() != (Card{Rank: 0, Suit: 0}) || a.Highest() != (Card{Rank: 12, Suit: 3}) {
+		t.Errorf("Lowest/Highest = %s/%s", a.Lowest(), a.Highest())
+	}
+	if got := a.Above(Card{Rank: 0, Suit: 3}).String(); got != "5D 2H" {
+		t.Errorf("Above(3H) = %q", got)
+	}
+	if got := a.Add(Card{Rank: 4, Suit: 1}).Remove(Card{Rank: 0, Suit: 0}).String(); got != "3H 5D 7C 2H" {
+		t.Errorf("Add/Remove = %q", got)
+	}
+	if FullDeck.Len() != 52 || !NewCardSet(NewDeck()).ContainsAll(FullDeck) {
+		t.Errorf("FullDeck does not hold the deck")
+	}
+}
+
+func TestCardSetRanksAndSuits(t *testing.T) {
+	s := NewCardSet(mustCards(t, "3S 3C 3H 9D JD 2S 2H"))
+
+	counts := s.RankCounts()
+	want := [13]int{0: 3, 6: 1, 8: 1, 12: 2}
+	if counts != want {
+		t.Errorf("RankCounts = %v, want %v", counts, want)
+	}
+	if s.RankCount(0) != 3 || s.RankCount(1) != 0 {
+		t.Errorf("RankCount mismatch")
+	}
+	if got := s.OfRank(12).String(); got != "2S 2H" {
+		t.Errorf("OfRank(2) = %q", got)
+	}
+	if got := s.OfSuit(2).String(); got != "9D JD" {
+		t.Errorf("OfSuit(Diamonds) = %q", got)
+	}
+	if !s.OfSuit(2).SameSuit() || s.SameSuit() || !s.OfRank(0).SameRank() || s.SameRank() {
+		t.Errorf("SameSuit/SameRank mismatch")
+	}
+	if got := FormatCards(s.Cards(), NotationText); got != "3S 3C 3H 9D JD 2S 2H" {
+		t.Errorf("Cards = %q, want sorted by power", got)
+	}
+}
+
+func TestCardSetCombinationType(t *testing.T) {
+	tests := []struct {
+		cards     string
+		want      CardCombinationType
+		wantPairs int
+	}{
+		{"", Invalid, 0},
+		{"7H", Single, 0},
+		{"7S 7H", Pair, 0},
+		{"7S 7C 7H", Triple, 0},
+		{"7S 7C 7D 7H", Bomb, 0},
+		{"3S 4D 5H", Straight, 0},
+		{"10S JD QH KC AS", Straight, 0},
+		{"KS AS 2S", Invalid, 0}, // 2 cannot be in a straight
+		{"3S 4D 6H", Invalid, 0}, // Gap
+		{"3S 3C 4D", Invalid, 0}, // Mixed counts
+		{"3S 3C 4D 4H 5S 5C", Bomb, 3},
+		{"3S 3C 4D 4H 5S 5C 6D 6H", Bomb, 4},
+		{"3S 3C 4D 4H 5S 5C 6D 6H 7S 7C", Bomb, 5},
+		{"3S 3C 4D 4H", Invalid, 0}, // Only two pairs
+		{"KS KC AD AH 2S 2C", Invalid, 0},
+		{"3S 3C 4D 4H 6S 6C", Invalid, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cards, func(t *testing.T) {
+			var cards []Card
+			if tt.cards != "" {
+				cards = mustCards(t, tt.cards)
+			}
+			s := NewCardSet(cards)
+			if got := s.CombinationType(); got != tt.want {
+				t.Errorf("CombinationType = %v, want %v", got, tt.want)
+			}
+			if got := s.ConsecutivePairs(); got != tt.wantPairs {
+				t.Errorf("ConsecutivePairs = %d, want %d", got, tt.wantPairs)
+			}
+		})
+	}
+}
+
+func TestIsValidSet_RejectsDuplicatesAndBadCards(t *testing.T) {
+	if IsValidSet([]Card{{Rank: 5, Suit: 0}, {Rank: 5, Suit: 0}}) {
+		t.Errorf("a card paired with itself must not be a valid set")
+	}
+	if IsValidSet([]Card{{Rank: 13, Suit: 0}}) {
+		t.Errorf("a card outside the deck must not be a valid set")
+	}
+}
+
+// benchmarkHand is a typical 13-card hand with pairs, a triple and a straight.
+const benchmarkHand = "3S 3C 4D 5H 6S 7C 7D 9H 9S 9D JC QH 2S"
+
+func BenchmarkIsValidSet(b *testing.B) {
+	pine := mustCards(b, "3S 3C 4D 4H 5S 5C")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		IsValidSet(pine)
+	}
+}
+
+func BenchmarkCanBeat(b *testing.B) {
+	prev := mustCards(b, "5S 6C 7D 8H 9S")
+	next := mustCards(b, "6S 7C 8D 9H 10S")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		CanBeat(prev, next)
+	}
+}
+
+func BenchmarkRemoveCards(b *testing.B) {
+	hand := mustCards(b, benchmarkHand)
+	played := mustCards(b, "9H 9S 9D")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		RemoveCards(hand, played)
+	}
+}
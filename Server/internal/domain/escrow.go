@@ -0,0 +1,125 @@
+package domain
+
+import "sort"
+
+// MaxLoss returns the most the player in seat can lose in this game, in gold: the worst settlement payout
+// or cong top-up, plus every 2 and bomb in their hand at the higher of its chop price and its leftover
+// penalty. Chopping back only ever gains, so each chop costs at most the price of the chopped cards.
+// An instant win at the deal is covered too. Call it on the dealt hands; an empty seat loses nothing.
+func (g *Game) MaxLoss(seat int) int64 {
+	var player *Player
+	for _, p := range g.Players {
+		if p.Seat == seat {
+			player = p
+		}
+	}
+	if player == nil {
+		return 0
+	}
+
+	loss := g.SettlementPolicy().MaxLoss(g, seat)
+	if cong := g.CongMultiplier * g.BaseBet; cong > loss {
+		loss = cong
+	}
+	loss += g.handExposure(player.Hand) * g.BaseBet
+
+	instantWin := g.InstantWinMultiplier
+	if instantWin <= 0 {
+		instantWin = 1
+	}
+	if instantWin*g.BaseBet > loss {
+		loss = instantWin * g.BaseBet
+	}
+	return loss
+}
+
+// handExposure returns the BaseBet multiplier a hand's 2s and bombs can cost, each one either chopped
+// or left over at the end. 2s may be played alone or in pairs, so every pairing is tried.
+func (g *Game) handExposure(hand []Card) int64 {
+	var twos []Card
+	var exposure int64
+	for _, item := range FindLeftoverPenalties(hand, g.RuleSet()) {
+		switch item.Reason {
+		case PenaltyBlackPig, PenaltyRedPig:
+			twos = append(twos, item.Cards...)
+		default:
+			bomb := CardCombination{Type: Bomb, Cards: item.Cards, Count: len(item.Cards)}
+			exposure += max(g.ChopMultiplier(bomb), g.LeftoverPenalties.rate(item.Reason))
+		}
+	}
+	return exposure + g.twosExposure(twos)
+}
+
+// twosExposure returns the costliest way of losing the given 2s as singles and pairs.
+func (g *Game) twosExposure(twos []Card) int64 {
+	if len(twos) == 0 {
+		return 0
+	}
+	single := func(c Card) int64 {
+		reason := PenaltyBlackPig
+		if c.Suit >= 2 {
+			reason = PenaltyRedPig
+		}
+		combo := CardCombination{Type: Single, Cards: []Card{c}, Count: 1}
+		return max(g.ChopMultiplier(combo), g.LeftoverPenalties.rate(reason))
+	}
+
+	first, rest := twos[0], twos[1:]
+	best := single(first) + g.twosExposure(rest)
+	for i, c := range rest {
+		pair := CardCombination{Type: Pair, Cards: []Card{first, c}, Count: 2}
+		others := append(append([]Card(nil), rest[:i]...), rest[i+1:]...)
+		if cost := g.ChopMultiplier(pair) + g.twosExposure(others); cost > best {
+			best = cost
+		}
+	}
+	return best
+}
+
+// CapToStakes limits each loser in changes to their stake and, when losers cannot cover what they owe,
+// scales every gain down by the share that was covered. Gold lost to rounding goes to the winners with the
+// largest remainders, so the gains add up to exactly that share. Players without a stake are not capped.
+// changes is modified in place.
+func CapToStakes(changes map[string]int64, stakes map[string]int64) {
+	var owed, covered, gains int64
+	type winner struct {
+		uid       string
+		amount    int64
+		remainder int64
+	}
+	var winners []winner
+	for uid, amount := range changes {
+		if amount > 0 {
+			gains += amount
+			winners = append(winners, winner{uid: uid, amount: amount})
+			continue
+		}
+		owed -= amount
+		if stake, ok := stakes[uid]; ok && -amount > stake {
+			changes[uid] = -stake
+		}
+		covered -= changes[uid]
+	}
+	if covered == owed {
+		return
+	}
+
+	target := gains * covered / owed
+	var paid int64
+	for i := range winners {
+		share := winners[i].amount * covered / owed
+		winners[i].remainder = winners[i].amount*covered - share*owed
+		changes[winners[i].uid] = share
+		paid += share
+	}
+	sort.Slice(winners, func(i, j int) bool {
+		if winners[i].remainder != winners[j].remainder {
+			return winners[i].remainder > winners[j].remainder
+		}
+		return winners[i].uid < winners[j].uid
+	})
+	for i := 0; paid < target; i++ {
+		changes[winners[i].uid]++
+		paid++
+	}
+}
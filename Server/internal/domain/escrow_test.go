@@ -0,0 +1,81 @@
+package domain
+
+import "testing"
+
+func TestMaxLoss_CoversPayoutAndEveryTwoAndBomb(t *testing.T) {
+	hand, err := ParseCards("2S 2H 7C 7D 7H 7S 3C 4D 5H 6S 8C 9D 10H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Game{
+		Players: map[string]*Player{
+			"u0": {UserID: "u0", Seat: 0, Hand: hand},
+			"u1": {UserID: "u1", Seat: 1},
+			"u2": {UserID: "u2", Seat: 2},
+		},
+		BaseBet:           100,
+		CongMultiplier:    3,
+		LeftoverPenalties: DefaultLeftoverPenaltyRates(),
+	}
+
+	// Cong (3) beats the worst rank payout (2); the 2s cost 3 as singles (1 + 2) or as a mixed pair;
+	// the quad costs 4.
+	if got, want := g.MaxLoss(0), int64((3+3+4)*100); got != want {
+		t.Errorf("MaxLoss(0) = %d, want %d", got, want)
+	}
+	if got := g.MaxLoss(3); got != 0 {
+		t.Errorf("MaxLoss of an empty seat = %d, want 0", got)
+	}
+}
+
+func TestCapToStakes(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes map[string]int64
+		stakes  map[string]int64
+		want    map[string]int64
+	}{
+		{
+			name:    "Covered",
+			changes: map[string]int64{"a": 300, "b": -100, "c": -200},
+			stakes:  map[string]int64{"a": 500, "b": 500, "c": 500},
+			want:    map[string]int64{"a": 300, "b": -100, "c": -200},
+		},
+		{
+			name:    "Short loser scales every gain",
+			changes: map[string]int64{"a": 200, "b": 100, "c": -300},
+			stakes:  map[string]int64{"a": 500, "b": 500, "c": 150},
+			want:    map[string]int64{"a": 100, "b": 50, "c": -150},
+		},
+		{
+			name:    "Rounding remainder goes to a winner",
+			changes: map[string]int64{"a": 100, "b": 100, "c": 100, "d": -300},
+			stakes:  map[string]int64{"d": 100},
+			want:    map[string]int64{"a": 34, "b": 33, "c": 33, "d": -100},
+		},
+		{
+			name:    "Players without a stake are not capped",
+			changes: map[string]int64{"a": 200, "bot": -100, "c": -100},
+			stakes:  map[string]int64{"a": 500, "c": 0},
+			want:    map[string]int64{"a": 100, "bot": -100, "c": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CapToStakes(tt.changes, tt.stakes)
+			var sum int64
+			for _, amount := range tt.changes {
+				sum += amount
+			}
+			if sum != 0 {
+				t.Errorf("capped changes %v sum to %d; gains must equal the covered total", tt.changes, sum)
+			}
+			for uid, want := range tt.want {
+				if tt.changes[uid] != want {
+					t.Errorf("changes = %v, want %v", tt.changes, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ServerSeedSize is the number of random bytes in a server seed.
+const ServerSeedSize = 32
+
+// ErrCommitmentMismatch is returned when a revealed server seed does not hash to the published commitment.
+var ErrCommitmentMismatch = errors.New("server seed does not match commitment")
+
+// DealSeed makes a shuffle provably fair by commit-reveal.
+// The server publishes Commitment() before the deal, mixes in a client-chosen salt, and reveals
+// ServerSeed once the game ends, so anyone can recompute the deck with VerifyDeal.
+type DealSeed struct {
+	ServerSeed []byte // Secret until the game ends
+	ClientSalt string // Chosen by a player when starting the game; may be empty
+}
+
+// Commitment returns the hex SHA-256 of the server seed.
+func (s DealSeed) Commitment() string {
+	sum := sha256.Sum256(s.ServerSeed)
+	return hex.EncodeToString(sum[:])
+}
+
+// ServerSeedHex returns the server seed in the hex form revealed to clients.
+func (s DealSeed) ServerSeedHex() string {
+	return hex.EncodeToString(s.ServerSeed)
+}
+
+// Shuffle returns a copy of deck in the order fixed by the seed.
+// The order is a Fisher-Yates shuffle driven by a SHA-256 counter stream keyed with
+// HMAC-SHA256(ServerSeed, ClientSalt), so neither party alone controls the deal.
+func (s DealSeed) Shuffle(deck []Card) []Card {
+	mac := hmac.New(sha256.New, s.ServerSeed)
+	mac.Write([]byte(s.ClientSalt))
+	stream := &seedStream{key: mac.Sum(nil)}
+
+	out := make([]Card, len(deck))
+	copy(out, deck)
+	for i := len(out) - 1; i > 0; i-- {
+		j := stream.intn(i + 1)
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// VerifyDeal checks a revealed server seed against its commitment and recomputes the shuffled deck.
+// Hands are dealt from the deck in 13-card blocks, one per occupied seat in seat order.
+func VerifyDeal(serverSeedHex, clientSalt, commitment string) ([]Card, error) {
+	serverSeed, err := hex.DecodeString(serverSeedHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server seed: %w", err)
+	}
+	seed := DealSeed{ServerSeed: serverSeed, ClientSalt: clientSalt}
+	if !hmac.Equal([]byte(seed.Commitment()), []byte(commitment)) {
+		return nil, ErrCommitmentMismatch
+	}
+	return seed.Shuffle(NewDeck()), nil
+}
+
+// seedStream is a deterministic byte stream: SHA-256(key || counter) for counter = 0, 1, 2...
+type seedStream struct {
+	key     []byte
+	counter uint64
+	buf     []byte
+}
+
+func (s *seedStream) uint64() uint64 {
+	if len(s.buf) < 8 {
+		var block [8]byte
+		binary.BigEndian.PutUint64(block[:], s.counter)
+		s.counter++
+		sum := sha256.Sum256(append(append([]byte(nil), s.key...), block[:]...))
+		s.buf = append(s.buf, sum[:]...)
+	}
+	v := binary.BigEndian.Uint64(s.buf[:8])
+	s.buf = s.buf[8:]
+	return v
+}
+
+// intn returns a uniform value in [0, n) using rejection sampling to avoid modulo bias.
+func (s *seedStream) intn(n int) int {
+	bound := uint64(n)
+	limit := ^uint64(0) - (^uint64(0) % bound)
+	for {
+		if v := s.uint64(); v < limit {
+			return int(v % bound)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDealSeedShuffle(t *testing.T) {
+	seed := DealSeed{ServerSeed: []byte("server-seed-0123456789abcdef0123"), ClientSalt: "lucky"}
+
+	first := seed.Shuffle(NewDeck())
+	second := seed.Shuffle(NewDeck())
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("shuffle is not deterministic at index %d: %v vs %v", i, first[i], second[i])
+		}
+	}
+
+	// The result is a permutation of the deck.
+	seen := make(map[Card]bool, len(first))
+	for _, c := range first {
+		if seen[c] {
+			t.Fatalf("card %v dealt twice", c)
+		}
+		seen[c] = true
+	}
+	if len(seen) != 52 {
+		t.Fatalf("shuffled deck has %d distinct cards, want 52", len(seen))
+	}
+
+	// A different client salt yields a different deal.
+	salted := DealSeed{ServerSeed: seed.ServerSeed, ClientSalt: "unlucky"}.Shuffle(NewDeck())
+	same := true
+	for i := range first {
+		if first[i] != salted[i] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Fatalf("changing the client salt did not change the deal")
+	}
+}
+
+func TestVerifyDeal(t *testing.T) {
+	seed := DealSeed{ServerSeed: []byte("server-seed-0123456789abcdef0123"), ClientSalt: "lucky"}
+	want := seed.Shuffle(NewDeck())
+
+	deck, err := VerifyDeal(seed.ServerSeedHex(), seed.ClientSalt, seed.Commitment())
+	if err != nil {
+		t.Fatalf("VerifyDeal error: %v", err)
+	}
+	for i := range want {
+		if deck[i] != want[i] {
+			t.Fatalf("recomputed deck differs at index %d: %v vs %v", i, deck[i], want[i])
+		}
+	}
+
+	other := DealSeed{ServerSeed: []byte("another-seed")}
+	if _, err := VerifyDeal(seed.ServerSeedHex(), seed.ClientSalt, other.Commitment()); !errors.Is(err, ErrCommitmentMismatch) {
+		t.Errorf("VerifyDeal with wrong commitment error = %v, want %v", err, ErrCommitmentMismatch)
+	}
+	if _, err := VerifyDeal("not-hex", "", seed.Commitment()); err == nil {
+		t.Errorf("VerifyDeal with malformed seed succeeded")
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+// Instant-win ("tới trắng") hand types detected right after dealing.
+const (
+	InstantWinDragonStraight       = "Dragon Straight"        // 3 through A, one of each rank
+	InstantWinFourTwos             = "Four Twos"              // All four 2s
+	InstantWinFiveConsecutivePairs = "Five Consecutive Pairs" // 5 pairs in a row (no 2s)
+	InstantWinSixPairs             = "Six Pairs"              // Any six pairs
+)
+
+// DetectInstantWin checks whether a freshly dealt hand wins immediately
+// and returns true plus the name of the instant-win type.
+// When a hand qualifies for several types, the strongest one is reported.
+// Five consecutive pairs only win under rules that have consecutive pairs.
+func DetectInstantWin(hand []Card, rules RuleSet) (bool, string) {
+	if len(hand) != 13 {
+		return false, ""
+	}
+
+	var rankCounts [13]int
+	for _, c := range hand {
+		if c.Rank < 0 || c.Rank > 12 {
+			return false, ""
+		}
+		rankCounts[c.Rank]++
+	}
+
+	// Dragon straight (sanh rong): one card of every rank from 3 (0) to A (11).
+	isDragon := true
+	for r := 0; r < 12; r++ {
+		if rankCounts[r] == 0 {
+			isDragon = false
+			break
+		}
+	}
+	if isDragon {
+		return true, InstantWinDragonStraight
+	}
+
+	// Four 2s (tu quy heo).
+	if rankCounts[12] == 4 {
+		return true, InstantWinFourTwos
+	}
+
+	// Five consecutive pairs (5 doi thong), 2s excluded.
+	run := 0
+	for r := 0; r < 12 && rules.HasConsecutivePairs(); r++ {
+		if rankCounts[r] >= 2 {
+			run++
+			if run >= 5 {
+				return true, InstantWinFiveConsecutivePairs
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	// Six pairs (6 doi): a triple counts as one pair, a quad as two.
+	pairs := 0
+	for _, count := range rankCounts {
+		pairs += count / 2
+	}
+	if pairs >= 6 {
+		return true, InstantWinSixPairs
+	}
+
+	return false, ""
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "testing"
+
+func TestDetectInstantWin(t *testing.T) {
+	tests := []struct {
+		name     string
+		hand     []Card
+		wantWin  bool
+		wantType string
+	}{
+		{
+			name: "Dragon Straight",
+			hand: []Card{
+				{Rank: 0, Suit: 0}, {Rank: 1, Suit: 1}, {Rank: 2, Suit: 2}, {Rank: 3, Suit: 3},
+				{Rank: 4, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 6, Suit: 2}, {Rank: 7, Suit: 3},
+				{Rank: 8, Suit: 0}, {Rank: 9, Suit: 1}, {Rank: 10, Suit: 2}, {Rank: 11, Suit: 3},
+				{Rank: 12, Suit: 0},
+			},
+			wantWin:  true,
+			wantType: InstantWinDragonStraight,
+		},
+		{
+			name: "Four Twos",
+			hand: []Card{
+				{Rank: 12, Suit: 0}, {Rank: 12, Suit: 1}, {Rank: 12, Suit: 2}, {Rank: 12, Suit: 3},
+				{Rank: 0, Suit: 0}, {Rank: 2, Suit: 1}, {Rank: 4, Suit: 2}, {Rank: 6, Suit: 3},
+				{Rank: 8, Suit: 0}, {Rank: 9, Suit: 1}, {Rank: 10, Suit: 2}, {Rank: 11, Suit: 3},
+				{Rank: 1, Suit: 0},
+			},
+			wantWin:  true,
+			wantType: InstantWinFourTwos,
+		},
+		{
+			name: "Five Consecutive Pairs",
+			hand: []Card{
+				{Rank: 3, Suit: 0}, {Rank: 3, Suit: 1}, {Rank: 4, Suit: 0}, {Rank: 4, Suit: 1},
+				{Rank: 5, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 6, Suit: 0}, {Rank: 6, Suit: 1},
+				{Rank: 7, Suit: 0}, {Rank: 7, Suit: 1}, {Rank: 0, Suit: 2}, {Rank: 10, Suit: 3},
+				{Rank: 12, Suit: 3},
+			},
+			wantWin:  true,
+			wantType: InstantWinFiveConsecutivePairs,
+		},
+		{
+			name: "Six Pairs (triple counts as one pair)",
+			hand: []Card{
+				{Rank: 0, Suit: 0}, {Rank: 0, Suit: 1}, {Rank: 2, Suit: 0}, {Rank: 2, Suit: 1},
+				{Rank: 4, Suit: 0}, {Rank: 4, Suit: 1}, {Rank: 6, Suit: 0}, {Rank: 6, Suit: 1},
+				{Rank: 8, Suit: 0}, {Rank: 8, Suit: 1}, {Rank: 10, Suit: 0}, {Rank: 10, Suit: 1},
+				{Rank: 10, Suit: 2},
+			},
+			wantWin:  true,
+			wantType: InstantWinSixPairs,
+		},
+		{
+			name: "Five Pairs Not Consecutive",
+			hand: []Card{
+				{Rank: 0, Suit: 0}, {Rank: 0, Suit: 1}, {Rank: 2, Suit: 0}, {Rank: 2, Suit: 1},
+				{Rank: 4, Suit: 0}, {Rank: 4, Suit: 1}, {Rank: 6, Suit: 0}, {Rank: 6, Suit: 1},
+				{Rank: 8, Suit: 0}, {Rank: 8, Suit: 1}, {Rank: 9, Suit: 0}, {Rank: 11, Suit: 1},
+				{Rank: 12, Suit: 2},
+			},
+			wantWin: false,
+		},
+		{
+			name:    "Short Hand",
+			hand:    []Card{{Rank: 12, Suit: 0}, {Rank: 12, Suit: 1}, {Rank: 12, Suit: 2}, {Rank: 12, Suit: 3}},
+			wantWin: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWin, gotType := DetectInstantWin(tt.hand, SouthernRules{})
+			if gotWin != tt.wantWin || gotType != tt.wantType {
+				t.Errorf("DetectInstantWin() = (%v, %q), want (%v, %q)", gotWin, gotType, tt.wantWin, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvariantViolation is wrapped by every error returned from Game.Validate.
+var ErrInvariantViolation = errors.New("game invariant violated")
+
+// Validate checks the game's structural invariants:
+//   - players are keyed by user ID and sit in distinct seats 0-3;
+//   - hands plus discards form a subset of the deck with no duplicates;
+//   - FinishOrderSeats lists each seat at most once and agrees with the Finished, Frozen and Forfeited flags;
+//   - while playing, CurrentTurn points at an active player who has not passed;
+//   - LastPlayedCombination was played by LastPlayerToPlaySeat and sits at the end of Discards.
+//
+// It returns nil or an error wrapping ErrInvariantViolation that describes the first violation found.
+func (g *Game) Validate() error {
+	if g == nil {
+		return violation("game is nil")
+	}
+
+	seats := make(map[int]*Player, len(g.Players))
+	for uid, p := range g.Players {
+		if p == nil {
+			return violation("player %q is nil", uid)
+		}
+		if p.UserID != uid {
+			return violation("player keyed %q has user ID %q", uid, p.UserID)
+		}
+		if p.Seat < 0 || p.Seat > 3 {
+			return violation("player %q sits in seat %d, want 0-3", uid, p.Seat)
+		}
+		if other, ok := seats[p.Seat]; ok {
+			return violation("players %q and %q share seat %d", other.UserID, uid, p.Seat)
+		}
+		seats[p.Seat] = p
+	}
+
+	if err := g.validateCards(seats); err != nil {
+		return err
+	}
+	if err := g.validateFinishOrder(seats); err != nil {
+		return err
+	}
+	if err := g.validateTurn(seats); err != nil {
+		return err
+	}
+	return g.validateLastPlay(seats)
+}
+
+// validateCards checks that every card in hands and discards is a real card held in exactly one place.
+func (g *Game) validateCards(seats map[int]*Player) error {
+	var where [52]string
+	place := func(c Card, location string) error {
+		if c.Rank < 0 || c.Rank > 12 || c.Suit < 0 || c.Suit > 3 {
+			return violation("%s holds invalid card {Rank:%d Suit:%d}", location, c.Rank, c.Suit)
+		}
+		idx := CardPower(c)
+		if where[idx] != "" {
+			return violation("card %s is in both %s and %s", c, where[idx], location)
+		}
+		where[idx] = location
+		return nil
+	}
+
+	for seat := 0; seat < 4; seat++ {
+		p, ok := seats[seat]
+		if !ok {
+			continue
+		}
+		location := fmt.Sprintf("seat %d hand", seat)
+		for _, c := range p.Hand {
+			if err := place(c, location); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range g.Discards {
+		if err := place(c, "discards"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFinishOrder checks FinishOrderSeats against the players' Finished, Frozen and Forfeited flags.
+// While playing, frozen and forfeited players are finished but only join the order when the game ends.
+// Once ended, the order ranks every player; an instant win never marks anyone Finished.
+func (g *Game) validateFinishOrder(seats map[int]*Player) error {
+	ranked := make(map[int]bool, len(g.FinishOrderSeats))
+	for i, seat := range g.FinishOrderSeats {
+		p, ok := seats[seat]
+		if !ok {
+			return violation("finish order position %d is empty seat %d", i+1, seat)
+		}
+		if ranked[seat] {
+			return violation("seat %d appears twice in finish order", seat)
+		}
+		ranked[seat] = true
+
+		if g.Phase == PhasePlaying && (!p.Finished || p.Frozen || p.Forfeited) {
+			return violation("seat %d is in finish order but finished=%t frozen=%t forfeited=%t", seat, p.Finished, p.Frozen, p.Forfeited)
+		}
+	}
+
+	for seat, p := range seats {
+		if (p.Frozen || p.Forfeited) && !p.Finished {
+			return violation("seat %d is frozen or forfeited but not finished", seat)
+		}
+		switch g.Phase {
+		case PhasePlaying:
+			if p.Finished && !p.Frozen && !p.Forfeited && !ranked[seat] {
+				return violation("seat %d is finished but missing from finish order", seat)
+			}
+		case PhaseEnded:
+			if !ranked[seat] {
+				return violation("game ended but seat %d is missing from finish order", seat)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTurn checks that the player on turn can act.
+func (g *Game) validateTurn(seats map[int]*Player) error {
+	if g.Phase != PhasePlaying {
+		return nil
+	}
+	p, ok := seats[g.CurrentTurn]
+	if !ok {
+		return violation("current turn is empty seat %d", g.CurrentTurn)
+	}
+	if p.Finished {
+		return violation("current turn seat %d has already finished", g.CurrentTurn)
+	}
+	if p.HasPassed {
+		return violation("current turn seat %d has already passed this round", g.CurrentTurn)
+	}
+	return nil
+}
+
+// validateLastPlay checks that the combination on the table matches the last player and the discards.
+func (g *Game) validateLastPlay(seats map[int]*Player) error {
+	combo := g.LastPlayedCombination
+	if g.LastPlayerToPlaySeat == -1 {
+		if combo.Type != Invalid || len(combo.Cards) > 0 {
+			return violation("table holds %d cards but nobody has played", len(combo.Cards))
+		}
+		if len(g.Discards) > 0 {
+			return violation("%d cards discarded but nobody has played", len(g.Discards))
+		}
+		return nil
+	}
+
+	if _, ok := seats[g.LastPlayerToPlaySeat]; !ok {
+		return violation("last play belongs to empty seat %d", g.LastPlayerToPlaySeat)
+	}
+	if combo.Type == Invalid {
+		// A new round has started; the table is clear.
+		if len(combo.Cards) > 0 {
+			return violation("cleared table still holds %d cards", len(combo.Cards))
+		}
+		return nil
+	}
+
+	if combo.Count != len(combo.Cards) {
+		return violation("table combination counts %d cards but holds %d", combo.Count, len(combo.Cards))
+	}
+	if len(combo.Cards) > len(g.Discards) {
+		return violation("table holds %d cards but only %d were discarded", len(combo.Cards), len(g.Discards))
+	}
+	tail := g.Discards[len(g.Discards)-len(combo.Cards):]
+	if !sameCards(tail, combo.Cards) {
+		return violation("table combination from seat %d is not the last discarded play", g.LastPlayerToPlaySeat)
+	}
+	return nil
+}
+
+// sameCards reports whether a and b hold the same cards in any order.
+func sameCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[Card]int, len(a))
+	for _, c := range a {
+		counts[c]++
+	}
+	for _, c := range b {
+		if counts[c] == 0 {
+			return false
+		}
+		counts[c]--
+	}
+	return true
+}
+
+func violation(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvariantViolation, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// validGame returns a two-player game mid-round: seat 0 played a pair of 3s, seat 1 is on turn.
+func validGame() *Game {
+	pair := []Card{{Rank: 0, Suit: 0}, {Rank: 0, Suit: 1}}
+	return &Game{
+		Phase: PhasePlaying,
+		Players: map[string]*Player{
+			"u0": {UserID: "u0", Seat: 0, Hand: []Card{{Rank: 5, Suit: 0}}, HasPlayed: true},
+			"u1": {UserID: "u1", Seat: 1, Hand: []Card{{Rank: 6, Suit: 2}, {Rank: 6, Suit: 3}}},
+		},
+		CurrentTurn:           1,
+		LastPlayedCombination: IdentifyCombination(append([]Card(nil), pair...)),
+		LastPlayerToPlaySeat:  0,
+		Discards:              pair,
+	}
+}
+
+func TestGameValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(g *Game)
+		wantErr string // Substring of the error; empty means valid
+	}{
+		{
+			name:   "Valid mid-round",
+			mutate: func(g *Game) {},
+		},
+		{
+			name: "Valid fresh table",
+			mutate: func(g *Game) {
+				g.LastPlayedCombination = CardCombination{}
+				g.LastPlayerToPlaySeat = -1
+				g.Discards = nil
+			},
+		},
+		{
+			name: "Card in hand and discards",
+			mutate: func(g *Game) {
+				g.Players["u0"].Hand = append(g.Players["u0"].Hand, Card{Rank: 0, Suit: 1})
+			},
+			wantErr: "is in both seat 0 hand and discards",
+		},
+		{
+			name: "Card duplicated across hands",
+			mutate: func(g *Game) {
+				g.Players["u0"].Hand = append(g.Players["u0"].Hand, Card{Rank: 6, Suit: 2})
+			},
+			wantErr: "is in both seat 0 hand and seat 1 hand",
+		},
+		{
+			name: "Invalid card",
+			mutate: func(g *Game) {
+				g.Players["u1"].Hand = append(g.Players["u1"].Hand, Card{Rank: 13, Suit: 0})
+			},
+			wantErr: "invalid card",
+		},
+		{
+			name:    "Turn on empty seat",
+			mutate:  func(g *Game) { g.CurrentTurn = 2 },
+			wantErr: "current turn is empty seat 2",
+		},
+		{
+			name:    "Turn on passed player",
+			mutate:  func(g *Game) { g.Players["u1"].HasPassed = true },
+			wantErr: "has already passed",
+		},
+		{
+			name: "Finished player missing from finish order",
+			mutate: func(g *Game) {
+				g.Players["u0"].Hand = nil
+				g.Players["u0"].Finished = true
+			},
+			wantErr: "missing from finish order",
+		},
+		{
+			name:    "Unfinished player in finish order",
+			mutate:  func(g *Game) { g.FinishOrderSeats = []int{0} },
+			wantErr: "is in finish order",
+		},
+		{
+			name:    "Table play not last discarded",
+			mutate:  func(g *Game) { g.Discards = append(g.Discards, Card{Rank: 9, Suit: 0}) },
+			wantErr: "is not the last discarded play",
+		},
+		{
+			name:    "Table play without player",
+			mutate:  func(g *Game) { g.LastPlayerToPlaySeat = -1 },
+			wantErr: "nobody has played",
+		},
+		{
+			name: "Ended game ranks everyone",
+			mutate: func(g *Game) {
+				g.Phase = PhaseEnded
+				g.FinishOrderSeats = []int{0}
+				g.Players["u0"].Finished = true
+			},
+			wantErr: "seat 1 is missing from finish order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := validGame()
+			tt.mutate(g)
+
+			err := g.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvariantViolation) {
+				t.Fatalf("Validate() = %v, want ErrInvariantViolation", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
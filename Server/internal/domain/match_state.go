@@ -1,5 +1,7 @@
 package domain
 
+import "sort"
+
 // Phase represents the lifecycle stage of a match.
 type Phase string
 
@@ -26,6 +28,9 @@ type Player struct {
 	Hand      []Card
 	HasPassed bool
 	Finished  bool
+	HasPlayed bool // Played at least one card this game
+	Frozen    bool // "Cong": never played before someone finished; out of play and ranked last
+	Forfeited bool // Left mid-game; out of play and ranked below everyone else (see Game.Forfeit)
 }
 
 // Game captures the pure domain state for a single game instance (playing phase).
@@ -37,51 +42,89 @@ type Game struct {
 	LastPlayedCombination CardCombination
 	LastPlayerToPlaySeat  int // Seat index (0-based)
 	BaseBet               int64
-	Discards              []Card // All cards played in this game so far
+	Discards              []Card               // All cards played in this game so far
+	InstantWin            string               // Instant-win type when the game ended at deal time (empty otherwise)
+	InstantWinMultiplier  int64                // BaseBet multiplier each loser pays on an instant win
+	LeftoverPenalties     LeftoverPenaltyRates // Charged for 2s and bombs left in a loser's hand
+	CongMultiplier        int64                // Minimum BaseBet multiplier a frozen ("cong") player pays
+	Rules                 RuleSet              // Regional rules; nil means Southern (see RuleSet())
+	ChopChain             []ChopLink           // Chops stacked in the current round ("chat chong"); reset on new round
+	ChopPenalties         ChopPenaltyTable     // Chop prices; the zero value means DefaultChopPenaltyTable()
+	Policy                SettlementPolicy     // Payouts and tax; nil means DefaultSettlementPolicy()
+	OpeningCard           *Card                // Card the first play must include; nil when not required or already played
+	Seed                  *DealSeed            // Provably fair shuffle seed; nil when the deck was supplied directly
+	ForfeitSeats          []int                // Seats that left mid-game, in leave order
+}
+
+// ChopLink is one play in the current round's chop chain. The first link is the chopped 2 or bomb.
+type ChopLink struct {
+	Seat     int
+	Cards    []Card
+	ChopType string // Empty for the original victim
+	Amount   int64  // Cumulative penalty paid by the previous link's owner for this chop
+}
+
+// RecordChop appends a chop of the last played combination to the chain and returns
+// the cumulative amount the chopped player owes: price for their own combination
+// plus everything they collected by chopping earlier in the chain.
+// It must be called before LastPlayedCombination is replaced by the chopping cards.
+func (g *Game) RecordChop(seat int, cards []Card, chopType string, price int64) int64 {
+	n := len(g.ChopChain)
+	if n == 0 || g.ChopChain[n-1].Seat != g.LastPlayerToPlaySeat {
+		// Start a new chain from the victim.
+		g.ChopChain = []ChopLink{{
+			Seat:  g.LastPlayerToPlaySeat,
+			Cards: append([]Card(nil), g.LastPlayedCombination.Cards...),
+		}}
+		n = 1
+	}
+
+	amount := price + g.ChopChain[n-1].Amount
+	g.ChopChain = append(g.ChopChain, ChopLink{
+		Seat:     seat,
+		Cards:    append([]Card(nil), cards...),
+		ChopType: chopType,
+		Amount:   amount,
+	})
+	return amount
 }
 
 // Settlement represents the net gold change for each player.
 type Settlement struct {
-	BalanceChanges map[string]int64 // UserID -> Gold (+/-)
+	BalanceChanges map[string]int64 // UserID -> Gold (+/-), after tax
+	Penalties      []PenaltyItem    // Line items already included in BalanceChanges
 }
 
-// CalculateSettlement computes the payouts based on finishing rank.
-// Payout Matrix (Multiplier * BaseBet):
-// 4 Players: 1st(+2), 2nd(+1), 3rd(-1), 4th(-2)
-// 3 Players: 1st(+3), 2nd(-1), 3rd(-2)
-// 2 Players: 1st(+1), 2nd(-1)
+// CalculateSettlement computes the payouts based on finishing rank using the game's SettlementPolicy.
+// Frozen ("cong") players pay at least CongMultiplier * BaseBet, the surplus going to the winner.
+// Losers still holding 2s or bombs then pay LeftoverPenalties to the winner.
+// On an instant win every loser pays InstantWinMultiplier * BaseBet to the winner instead.
+// The policy's tax is withheld from positive changes last.
 func (g *Game) CalculateSettlement() Settlement {
+	policy := g.SettlementPolicy()
+	if g.InstantWin != "" && len(g.FinishOrderSeats) > 0 {
+		settlement := g.calculateInstantWinSettlement()
+		policy.ApplyTax(settlement.BalanceChanges)
+		return settlement
+	}
+
 	changes := make(map[string]int64)
 	playerCount := len(g.Players)
-	
+
 	// Create a map of seat index to user ID for easy lookup
 	seatToUser := make(map[int]string)
 	for uid, p := range g.Players {
 		seatToUser[p.Seat] = uid
 	}
 
-	// Determine multipliers based on player count
-	var multipliers []int64
-	switch playerCount {
-	case 4:
-		multipliers = []int64{2, 1, -1, -2}
-	case 3:
-		multipliers = []int64{3, -1, -2}
-	case 2:
-		multipliers = []int64{1, -1}
-	default:
-		// Fallback for 1 player (testing) or >4 (error)
-		multipliers = make([]int64, playerCount)
-	}
-
 	// Apply payouts based on rank order
 	// FinishOrderSeats contains seat indices of players in order of finishing (1st, 2nd...)
 	// Note: Players who haven't finished yet are implicitly last.
 	// We need to construct a full ordered list including those still playing (if forced end).
-	
+
 	fullRankOrder := make([]int, 0, playerCount)
 	fullRankOrder = append(fullRankOrder, g.FinishOrderSeats...)
-	
+
 	// Add remaining players who haven't finished (shouldn't happen in normal flow as game ends when 1 left)
 	// But for safety, add them.
 	present := make(map[int]bool)
@@ -98,19 +141,176 @@ func (g *Game) CalculateSettlement() Settlement {
 	}
 
 	// Calculate changes
-	for rank, seat := range fullRankOrder {
-		if rank >= len(multipliers) {
-			break
+	payouts := policy.Payouts(g, fullRankOrder)
+	for _, seat := range fullRankOrder {
+		changes[seatToUser[seat]] = payouts[seat]
+	}
+
+	penalties := g.congPenalties(changes, seatToUser)
+	penalties = append(penalties, g.leftoverPenalties()...)
+	for _, item := range penalties {
+		changes[seatToUser[item.PayerSeat]] -= item.Amount
+		changes[seatToUser[item.PayeeSeat]] += item.Amount
+	}
+
+	policy.ApplyTax(changes)
+	return Settlement{BalanceChanges: changes, Penalties: penalties}
+}
+
+// calculateInstantWinSettlement charges every loser the instant-win multiplier, paid to the winner.
+func (g *Game) calculateInstantWinSettlement() Settlement {
+	changes := make(map[string]int64)
+	winnerSeat := g.FinishOrderSeats[0]
+
+	multiplier := g.InstantWinMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	amount := multiplier * g.BaseBet
+
+	var winnerID string
+	var collected int64
+	for uid, p := range g.Players {
+		if p.Seat == winnerSeat {
+			winnerID = uid
+			continue
 		}
-		
-		uid := seatToUser[seat]
-		amount := multipliers[rank] * g.BaseBet
-		changes[uid] = amount
+		changes[uid] = -amount
+		collected += amount
+	}
+	if winnerID != "" {
+		changes[winnerID] = collected
 	}
 
 	return Settlement{BalanceChanges: changes}
 }
 
+// FreezeUnplayedPlayers marks every unfinished player who has not played a card yet as frozen ("cong").
+// It is called when the first player finishes; frozen players are treated as finished from then on.
+// Newly frozen players are returned in seat order.
+func (g *Game) FreezeUnplayedPlayers() []*Player {
+	var frozen []*Player
+	for _, p := range g.Players {
+		if !p.Finished && !p.HasPlayed {
+			p.Frozen = true
+			p.Finished = true
+			frozen = append(frozen, p)
+		}
+	}
+	sort.Slice(frozen, func(i, j int) bool { return frozen[i].Seat < frozen[j].Seat })
+	return frozen
+}
+
+// FrozenSeats returns the seats of frozen players in seat order.
+func (g *Game) FrozenSeats() []int {
+	var seats []int
+	for _, p := range g.Players {
+		if p.Frozen {
+			seats = append(seats, p.Seat)
+		}
+	}
+	sort.Ints(seats)
+	return seats
+}
+
+// Forfeit takes a player who left mid-game out of play. They count as finished, so turn order skips
+// them, but they keep their hand: at the end they are ranked below every other player, the first to
+// leave lowest, and settle from there, leftover penalties included. An opening card in their hand
+// is no longer required. It reports false when the seat is empty or has already finished.
+func (g *Game) Forfeit(seat int) bool {
+	for _, p := range g.Players {
+		if p.Seat != seat {
+			continue
+		}
+		if p.Finished {
+			return false
+		}
+		p.Finished = true
+		p.Forfeited = true
+		p.HasPassed = false
+		g.ForfeitSeats = append(g.ForfeitSeats, seat)
+		if g.OpeningCard != nil && NewCardSet(p.Hand).Has(*g.OpeningCard) {
+			g.OpeningCard = nil
+		}
+		return true
+	}
+	return false
+}
+
+// CompleteFinishOrder appends every player missing from FinishOrderSeats:
+// players still holding cards first, then frozen players, each group in seat order,
+// then forfeited players, the last to leave first.
+func (g *Game) CompleteFinishOrder() {
+	present := make(map[int]bool, len(g.FinishOrderSeats))
+	for _, seat := range g.FinishOrderSeats {
+		present[seat] = true
+	}
+
+	var active, frozen []int
+	for _, p := range g.Players {
+		if present[p.Seat] || p.Forfeited {
+			continue
+		}
+		if p.Frozen {
+			frozen = append(frozen, p.Seat)
+		} else {
+			active = append(active, p.Seat)
+		}
+	}
+	sort.Ints(active)
+	sort.Ints(frozen)
+
+	g.FinishOrderSeats = append(g.FinishOrderSeats, active...)
+	g.FinishOrderSeats = append(g.FinishOrderSeats, frozen...)
+	for i := len(g.ForfeitSeats) - 1; i >= 0; i-- {
+		if seat := g.ForfeitSeats[i]; !present[seat] {
+			g.FinishOrderSeats = append(g.FinishOrderSeats, seat)
+		}
+	}
+}
+
+// congPenalties tops up each frozen player's loss to CongMultiplier * BaseBet, paid to the winner.
+func (g *Game) congPenalties(changes map[string]int64, seatToUser map[int]string) []PenaltyItem {
+	if g.CongMultiplier <= 0 || len(g.FinishOrderSeats) == 0 {
+		return nil
+	}
+	winnerSeat := g.FinishOrderSeats[0]
+	minLoss := g.CongMultiplier * g.BaseBet
+
+	var items []PenaltyItem
+	for _, seat := range g.FrozenSeats() {
+		uid := seatToUser[seat]
+		extra := minLoss + changes[uid] // changes[uid] is already negative
+		if extra <= 0 || seat == winnerSeat {
+			continue
+		}
+		items = append(items, PenaltyItem{
+			PayerSeat: seat,
+			PayeeSeat: winnerSeat,
+			Reason:    PenaltyCong,
+			Amount:    extra,
+		})
+	}
+	return items
+}
+
+// FindOpeningCard returns the lowest card dealt across all hands: the 3 of Spades at a full table.
+func (g *Game) FindOpeningCard() (Card, bool) {
+	var lowest Card
+	found := false
+	for _, p := range g.Players {
+		if len(p.Hand) == 0 {
+			continue
+		}
+		smallest := GetSmallestCard(p.Hand)
+		if !found || CardPower(smallest) < CardPower(lowest) {
+			lowest = smallest
+			found = true
+		}
+	}
+	return lowest, found
+}
+
 // CountPlayersWithCards returns the number of active players with cards remaining.
 func CountPlayersWithCards(game *Game) int {
 	count := 0
@@ -123,23 +323,18 @@ func CountPlayersWithCards(game *Game) int {
 }
 
 // RemoveCards removes the specified cards from a hand and returns the updated hand.
+// The hand keeps its order; the input slice is not modified.
 func RemoveCards(hand []Card, toRemove []Card) []Card {
 	if len(toRemove) == 0 || len(hand) == 0 {
 		return hand
 	}
 
-	removeCounts := make(map[Card]int, len(toRemove))
-	for _, card := range toRemove {
-		removeCounts[card]++
-	}
-
+	remove := NewCardSet(toRemove)
 	updated := make([]Card, 0, len(hand))
 	for _, card := range hand {
-		if count, ok := removeCounts[card]; ok && count > 0 {
-			removeCounts[card] = count - 1
-			continue
+		if !remove.Has(card) {
+			updated = append(updated, card)
 		}
-		updated = append(updated, card)
 	}
 
 	return updated
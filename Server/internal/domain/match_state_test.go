@@ -78,3 +78,100 @@ func TestCalculateSettlement(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateSettlement_InstantWin(t *testing.T) {
+	players := map[string]*Player{
+		"u0": {UserID: "u0", Seat: 0},
+		"u1": {UserID: "u1", Seat: 1},
+		"u2": {UserID: "u2", Seat: 2},
+	}
+	game := &Game{
+		Players:              players,
+		FinishOrderSeats:     []int{1, 0, 2},
+		BaseBet:              100,
+		InstantWin:           InstantWinDragonStraight,
+		InstantWinMultiplier: 3,
+	}
+
+	settlement := game.CalculateSettlement()
+
+	want := map[string]int64{
+		"u1": 600,  // Collects 3x from each of the two losers
+		"u0": -300, // -3
+		"u2": -300, // -3
+	}
+	for uid, amount := range want {
+		if got := settlement.BalanceChanges[uid]; got != amount {
+			t.Errorf("player %s: got %d, want %d", uid, got, amount)
+		}
+	}
+}
+
+func TestCalculateSettlement_Cong(t *testing.T) {
+	game := &Game{
+		Players: map[string]*Player{
+			"u0": {UserID: "u0", Seat: 0, Finished: true},
+			"u1": {UserID: "u1", Seat: 1, Finished: true},
+			"u2": {UserID: "u2", Seat: 2, Finished: true, Frozen: true},
+			"u3": {UserID: "u3", Seat: 3},
+		},
+		FinishOrderSeats: []int{0, 1},
+		BaseBet:          100,
+		CongMultiplier:   3,
+	}
+
+	game.CompleteFinishOrder()
+	wantOrder := []int{0, 1, 3, 2}
+	for i, seat := range wantOrder {
+		if game.FinishOrderSeats[i] != seat {
+			t.Fatalf("finish order = %v, want %v", game.FinishOrderSeats, wantOrder)
+		}
+	}
+
+	settlement := game.CalculateSettlement()
+	expected := map[string]int64{
+		"u0": 300,  // +2, plus the cong top-up
+		"u1": 100,  // +1
+		"u3": -100, // -1
+		"u2": -300, // -2 for last place, topped up to -3
+	}
+	for uid, want := range expected {
+		if got := settlement.BalanceChanges[uid]; got != want {
+			t.Errorf("%s change = %d, want %d", uid, got, want)
+		}
+	}
+	if len(settlement.Penalties) != 1 || settlement.Penalties[0].Reason != PenaltyCong || settlement.Penalties[0].Amount != 100 {
+		t.Errorf("penalties = %+v, want one cong top-up of 100", settlement.Penalties)
+	}
+}
+
+func TestCompleteFinishOrder_RanksForfeitsBelowFrozen(t *testing.T) {
+	game := &Game{
+		Phase: PhasePlaying,
+		Players: map[string]*Player{
+			"a": {UserID: "a", Seat: 0, Hand: []Card{{Rank: 0, Suit: 0}}},
+			"b": {UserID: "b", Seat: 1, Finished: true},
+			"c": {UserID: "c", Seat: 2, Hand: []Card{{Rank: 1, Suit: 0}}, Finished: true, Frozen: true},
+			"d": {UserID: "d", Seat: 3, Hand: []Card{{Rank: 2, Suit: 0}}},
+		},
+		FinishOrderSeats: []int{1},
+	}
+
+	if !game.Forfeit(3) || !game.Forfeit(0) {
+		t.Fatal("expected both unfinished seats to forfeit")
+	}
+	if game.Forfeit(1) || game.Forfeit(2) {
+		t.Fatal("finished and frozen players cannot forfeit")
+	}
+
+	game.CompleteFinishOrder()
+	want := []int{1, 2, 0, 3} // Finisher, frozen, then the last to leave above the first
+	if len(game.FinishOrderSeats) != len(want) {
+		t.Fatalf("finish order = %v, want %v", game.FinishOrderSeats, want)
+	}
+	for i := range want {
+		if game.FinishOrderSeats[i] != want[i] {
+			t.Fatalf("finish order = %v, want %v", game.FinishOrderSeats, want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package domain
+
+// moveTypeOrder is the order LegalMoveSet.All lists combination types in.
+var moveTypeOrder = []CardCombinationType{Single, Pair, Triple, Straight, Bomb}
+
+// LegalMoveSet holds every legal play from a hand, grouped by combination type.
+// Within a type, moves are listed lowest rank first.
+type LegalMoveSet struct {
+	ByType map[CardCombinationType][]CardCombination
+	// Chops lists the moves that chop the combination on the table (see RuleSet.DetectChop).
+	// Each one also appears in ByType.
+	Chops []CardCombination
+}
+
+// LegalMoves enumerates every play from hand that the rule set accepts on top of lastCombo.
+// On a fresh table (lastCombo.Type == Invalid) any valid combination is legal; otherwise each move
+// must pass rules.CanBeat, which includes chopping 2s and smaller bombs.
+// Every suit choice is listed, e.g. a straight through two 5s appears twice.
+func LegalMoves(hand []Card, lastCombo CardCombination, rules RuleSet) LegalMoveSet {
+	if rules == nil {
+		rules = SouthernRules{}
+	}
+	set := NewCardSet(hand)
+	moves := LegalMoveSet{ByType: make(map[CardCombinationType][]CardCombination)}
+	leading := lastCombo.Type == Invalid
+
+	add := func(candidate CardSet) {
+		combo := rules.IdentifyCombination(candidate.Cards())
+		if combo.Type == Invalid {
+			return
+		}
+		if !leading {
+			if !rules.CanBeat(lastCombo.Cards, combo.Cards) {
+				return
+			}
+			if chop, _ := rules.DetectChop(lastCombo.Cards, combo.Cards); chop {
+				moves.Chops = append(moves.Chops, combo)
+			}
+		}
+		moves.ByType[combo.Type] = append(moves.ByType[combo.Type], combo)
+	}
+
+	// When responding, only plays of the table's size or bombs (quads, consecutive pairs) can be legal,
+	// so skip generating shapes that CanBeat would reject.
+	size := len(lastCombo.Cards)
+	wants := func(n int, bomb bool) bool { return leading || n == size || bomb }
+
+	counts := set.RankCounts()
+	for r := int32(0); r <= 12; r++ {
+		forEachSubset(set.OfRank(r), func(sub CardSet) {
+			if n := sub.Len(); wants(n, n == 4) {
+				add(sub)
+			}
+		})
+	}
+
+	// Straights and consecutive pairs never contain 2s (rank 12).
+	for start := int32(0); start < 12; start++ {
+		for end := start + 2; end < 12 && rankRunHeld(counts, start, end, 1); end++ {
+			if wants(int(end-start)+1, false) {
+				forEachRun(set, start, end, 1, 0, add)
+			}
+		}
+		for end := start + 2; end < 12 && rankRunHeld(counts, start, end, 2); end++ {
+			forEachRun(set, start, end, 2, 0, add)
+		}
+	}
+	return moves
+}
+
+// LegalMoves returns the legal plays for the player in seat, restricted to plays that include
+// the opening card while one is required. It is empty when the seat is empty or has finished.
+func (g *Game) LegalMoves(seat int) LegalMoveSet {
+	return g.View(seat).LegalMoves()
+}
+
+// All returns every move: singles, pairs, triples, straights, then bombs.
+func (m LegalMoveSet) All() []CardCombination {
+	var all []CardCombination
+	for _, t := range moveTypeOrder {
+		all = append(all, m.ByType[t]...)
+	}
+	return all
+}
+
+// Of returns the moves of one combination type.
+func (m LegalMoveSet) Of(t CardCombinationType) []CardCombination {
+	return m.ByType[t]
+}
+
+// Len returns the number of moves.
+func (m LegalMoveSet) Len() int {
+	n := 0
+	for _, moves := range m.ByType {
+		n += len(moves)
+	}
+	return n
+}
+
+// Contains reports whether playing exactly cards is one of the moves.
+func (m LegalMoveSet) Contains(cards []Card) bool {
+	want := NewCardSet(cards)
+	if want.Len() != len(cards) {
+		return false
+	}
+	for _, moves := range m.ByType {
+		for _, move := range moves {
+			if NewCardSet(move.Cards) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Containing returns the moves that include card, e.g. the opening card of a fresh table.
+func (m LegalMoveSet) Containing(card Card) LegalMoveSet {
+	keep := func(moves []CardCombination) []CardCombination {
+		var kept []CardCombination
+		for _, move := range moves {
+			if NewCardSet(move.Cards).Has(card) {
+				kept = append(kept, move)
+			}
+		}
+		return kept
+	}
+
+	filtered := LegalMoveSet{ByType: make(map[CardCombinationType][]CardCombination), Chops: keep(m.Chops)}
+	for t, moves := range m.ByType {
+		if kept := keep(moves); len(kept) > 0 {
+			filtered.ByType[t] = kept
+		}
+	}
+	return filtered
+}
+
+// rankRunHeld reports whether every rank from start to end is held at least perRank times.
+func rankRunHeld(counts [13]int, start, end int32, perRank int) bool {
+	for r := start; r <= end; r++ {
+		if counts[r] < perRank {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachSubset calls fn with every non-empty subset of a single rank's cards.
+func forEachSubset(rank CardSet, fn func(CardSet)) {
+	if rank.IsEmpty() {
+		return
+	}
+	shift := uint(CardPower(rank.Lowest())) &^ 3 // First bit of the rank's nibble
+	nibble := uint64(rank) >> shift
+	for sub := uint64(1); sub <= nibble; sub++ {
+		if sub&^nibble == 0 {
+			fn(CardSet(sub << shift))
+		}
+	}
+}
+
+// forEachRun calls fn with every way of adding perRank cards from each rank from start to end to picked.
+func forEachRun(set CardSet, start, end int32, perRank int, picked CardSet, fn func(CardSet)) {
+	if start > end {
+		fn(picked)
+		return
+	}
+	forEachSubset(set.OfRank(start), func(sub CardSet) {
+		if sub.Len() == perRank {
+			forEachRun(set, start+1, end, perRank, picked|sub, fn)
+		}
+	})
+}
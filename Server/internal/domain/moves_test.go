@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteForceMoves checks every subset of hand against the rule set, the definition LegalMoves must match.
+func bruteForceMoves(hand []Card, lastCombo CardCombination, rules RuleSet) map[CardSet]bool {
+	want := make(map[CardSet]bool)
+	for mask := 1; mask < 1<<len(hand); mask++ {
+		var cards []Card
+		for i, c := range hand {
+			if mask&(1<<i) != 0 {
+				cards = append(cards, c)
+			}
+		}
+		if !rules.IsValidSet(cards) {
+			continue
+		}
+		if lastCombo.Type != Invalid && !rules.CanBeat(lastCombo.Cards, cards) {
+			continue
+		}
+		want[NewCardSet(cards)] = true
+	}
+	return want
+}
+
+func TestLegalMoves_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	for _, rules := range []RuleSet{SouthernRules{}, NorthernRules{}} {
+		for i := 0; i < 40; i++ {
+			deck := NewDeck()
+			rng.Shuffle(len(deck), func(a, b int) { deck[a], deck[b] = deck[b], deck[a] })
+			hand := deck[:13]
+
+			// Lead, then respond to a random legal lead from another hand.
+			tables := []CardCombination{{Type: Invalid}}
+			if leads := LegalMoves(deck[13:26], CardCombination{Type: Invalid}, rules).All(); len(leads) > 0 {
+				tables = append(tables, leads[rng.Intn(len(leads))])
+			}
+
+			for _, table := range tables {
+				want := bruteForceMoves(hand, table, rules)
+				got := LegalMoves(hand, table, rules)
+
+				seen := make(map[CardSet]bool)
+				for _, move := range got.All() {
+					set := NewCardSet(move.Cards)
+					if !want[set] {
+						t.Fatalf("%s on %v: %s is not legal", rules.Name(), table.Cards, set)
+					}
+					if seen[set] {
+						t.Fatalf("%s on %v: %s listed twice", rules.Name(), table.Cards, set)
+					}
+					seen[set] = true
+				}
+				if len(seen) != len(want) || got.Len() != len(want) {
+					t.Fatalf("%s on %v: got %d moves, want %d", rules.Name(), table.Cards, len(seen), len(want))
+				}
+			}
+		}
+	}
+}
+
+func TestLegalMoves_GroupsByTypeAndFlagsChops(t *testing.T) {
+	hand := mustCards(t, "3S 3C 4D 4H 5S 5C 9S 9C 9D 9H KD")
+	two := IdentifyCombination(mustCards(t, "2H"))
+
+	moves := LegalMoves(hand, two, SouthernRules{})
+	if n := len(moves.Of(Single)) + len(moves.Of(Pair)) + len(moves.Of(Straight)); n != 0 {
+		t.Errorf("only bombs beat a single 2, got %d other moves", n)
+	}
+	if got := len(moves.Of(Bomb)); got != 2 {
+		t.Fatalf("bombs = %d, want the 3-Pine and the quad", got)
+	}
+	if len(moves.Chops) != 2 {
+		t.Errorf("chops = %d, want both bombs", len(moves.Chops))
+	}
+	for _, combo := range moves.Of(Bomb) {
+		if !moves.Contains(combo.Cards) {
+			t.Errorf("Contains(%s) = false", FormatCards(combo.Cards, NotationText))
+		}
+	}
+
+	lead := LegalMoves(hand, CardCombination{Type: Invalid}, SouthernRules{})
+	if len(lead.Chops) != 0 {
+		t.Errorf("a lead chops nothing, got %d chops", len(lead.Chops))
+	}
+	wantCounts := map[CardCombinationType]int{
+		Single:   11,
+		Pair:     9, // One each of 3s, 4s and 5s plus C(4,2) from the 9s
+		Triple:   4,
+		Straight: 8, // 3-4-5 with two suit choices per rank
+		Bomb:     2,
+	}
+	for typ, want := range wantCounts {
+		if got := len(lead.Of(typ)); got != want {
+			t.Errorf("lead %v moves = %d, want %d", typ, got, want)
+		}
+	}
+}
+
+func TestGameLegalMoves_OpeningCard(t *testing.T) {
+	g := &Game{
+		Phase: PhasePlaying,
+		Players: map[string]*Player{
+			"u0": {UserID: "u0", Seat: 0, Hand: mustCards(t, "3S 3H 4D 5C 9H")},
+		},
+		LastPlayerToPlaySeat: -1,
+		OpeningCard:          &Card{Rank: 0, Suit: 0},
+	}
+
+	moves := g.LegalMoves(0)
+	if moves.Len() == 0 {
+		t.Fatal("expected moves containing the opening card")
+	}
+	for _, move := range moves.All() {
+		if !NewCardSet(move.Cards).Has(*g.OpeningCard) {
+			t.Errorf("move %s does not include the opening card", FormatCards(move.Cards, NotationText))
+		}
+	}
+	if g.LegalMoves(2).Len() != 0 {
+		t.Errorf("empty seat has moves")
+	}
+}
+
+func BenchmarkLegalMoves_Lead(b *testing.B) {
+	hand := mustCards(b, benchmarkHand)
+	lead := CardCombination{Type: Invalid}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		LegalMoves(hand, lead, SouthernRules{})
+	}
+}
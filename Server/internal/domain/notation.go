@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CardNotation selects how cards are written as text.
+type CardNotation int
+
+const (
+	// NotationText writes rank then suit letter: "3H", "10S", "AD". It is the canonical form.
+	NotationText CardNotation = iota
+	// NotationUnicode writes rank then suit symbol: "3♥", "10♠", "A♦".
+	NotationUnicode
+	// NotationCompact writes exactly two characters, with T for 10: "3H", "TS", "AD".
+	NotationCompact
+)
+
+// Rank and suit spellings indexed by Card.Rank (0 = 3 ... 12 = 2) and Card.Suit (0 = Spades ... 3 = Hearts).
+var (
+	rankText        = [13]string{"3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A", "2"}
+	rankCompact     = [13]string{"3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K", "A", "2"}
+	suitLetters     = [4]string{"S", "C", "D", "H"}
+	suitSymbols     = [4]string{"♠", "♣", "♦", "♥"}
+	suitSymbolsOpen = [4]string{"♤", "♧", "♢", "♡"} // Accepted when parsing only
+)
+
+// String returns the card in NotationText, so cards log as "10S" rather than raw rank/suit ints.
+func (c Card) String() string {
+	return c.Format(NotationText)
+}
+
+// Format writes the card in the given notation. Out-of-range ranks or suits are written as "?".
+func (c Card) Format(n CardNotation) string {
+	rank, suit := "?", "?"
+	if c.Rank >= 0 && c.Rank <= 12 {
+		if n == NotationCompact {
+			rank = rankCompact[c.Rank]
+		} else {
+			rank = rankText[c.Rank]
+		}
+	}
+	if c.Suit >= 0 && c.Suit <= 3 {
+		if n == NotationUnicode {
+			suit = suitSymbols[c.Suit]
+		} else {
+			suit = suitLetters[c.Suit]
+		}
+	}
+	return rank + suit
+}
+
+// FormatCards writes cards in the given notation separated by spaces, e.g. "3S 4S 10H".
+// The output round-trips through ParseCards.
+func FormatCards(cards []Card, n CardNotation) string {
+	parts := make([]string, len(cards))
+	for i, c := range cards {
+		parts[i] = c.Format(n)
+	}
+	return strings.Join(parts, " ")
+}
+
+// SplitCardTokens splits a card list on commas, semicolons or whitespace.
+func SplitCardTokens(input string) []string {
+	return strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+}
+
+// ParseCards parses a comma, semicolon or space delimited list such as "3H, 10S" or "3♥ TS".
+func ParseCards(input string) ([]Card, error) {
+	tokens := SplitCardTokens(input)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no cards in %q", input)
+	}
+
+	cards := make([]Card, 0, len(tokens))
+	for _, token := range tokens {
+		card, err := ParseCard(token)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// ParseCard parses a single card in any notation: "3H", "10s", "TS", "3♥", "10♡". Case is ignored.
+func ParseCard(token string) (Card, error) {
+	token = strings.ToUpper(strings.TrimSpace(token))
+	suitRune, size := utf8.DecodeLastRuneInString(token)
+	if size == 0 || len(token) == size {
+		return Card{}, fmt.Errorf("invalid card token %q: too short", token)
+	}
+
+	rank, ok := parseRank(token[:len(token)-size])
+	if !ok {
+		return Card{}, fmt.Errorf("invalid card token %q: unknown rank", token)
+	}
+	suit, ok := parseSuit(string(suitRune))
+	if !ok {
+		return Card{}, fmt.Errorf("invalid card token %q: unknown suit", token)
+	}
+	return Card{Rank: rank, Suit: suit}, nil
+}
+
+func parseRank(text string) (int32, bool) {
+	for r := int32(0); r <= 12; r++ {
+		if text == rankText[r] || text == rankCompact[r] {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+func parseSuit(text string) (int32, bool) {
+	for s := int32(0); s <= 3; s++ {
+		if text == suitLetters[s] || text == suitSymbols[s] || text == suitSymbolsOpen[s] {
+			return s, true
+		}
+	}
+	return 0, false
+}
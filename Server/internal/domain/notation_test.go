@@ -0,0 +1,90 @@
+package domain
+
+import "testing"
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Card
+		wantErr bool
+	}{
+		{
+			name:  "Text notation",
+			input: "3H, 10S; 2d",
+			want:  []Card{{Rank: 0, Suit: 3}, {Rank: 7, Suit: 0}, {Rank: 12, Suit: 2}},
+		},
+		{
+			name:  "Compact notation",
+			input: "TS JC AH",
+			want:  []Card{{Rank: 7, Suit: 0}, {Rank: 8, Suit: 1}, {Rank: 11, Suit: 3}},
+		},
+		{
+			name:  "Unicode suits",
+			input: "3♠ 10♥ K♢",
+			want:  []Card{{Rank: 0, Suit: 0}, {Rank: 7, Suit: 3}, {Rank: 10, Suit: 2}},
+		},
+		{name: "Unknown rank", input: "1S", wantErr: true},
+		{name: "Unknown suit", input: "3X", wantErr: true},
+		{name: "Missing suit", input: "Q", wantErr: true},
+		{name: "Empty", input: " , ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCards(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCards(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCards(%q) error: %v", tt.input, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseCards(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseCards(%q)[%d] = %v, want %v", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatCards(t *testing.T) {
+	cards := []Card{{Rank: 0, Suit: 0}, {Rank: 7, Suit: 3}, {Rank: 12, Suit: 2}}
+
+	tests := []struct {
+		notation CardNotation
+		want     string
+	}{
+		{NotationText, "3S 10H 2D"},
+		{NotationUnicode, "3♠ 10♥ 2♦"},
+		{NotationCompact, "3S TH 2D"},
+	}
+
+	for _, tt := range tests {
+		got := FormatCards(cards, tt.notation)
+		if got != tt.want {
+			t.Errorf("FormatCards(notation %d) = %q, want %q", tt.notation, got, tt.want)
+		}
+
+		// Every notation round-trips through the parser.
+		parsed, err := ParseCards(got)
+		if err != nil {
+			t.Fatalf("ParseCards(%q) error: %v", got, err)
+		}
+		for i := range cards {
+			if parsed[i] != cards[i] {
+				t.Errorf("round trip of %q: card %d = %v, want %v", got, i, parsed[i], cards[i])
+			}
+		}
+	}
+
+	if got := (Card{Rank: 7, Suit: 0}).String(); got != "10S" {
+		t.Errorf("Card.String() = %q, want 10S", got)
+	}
+}
@@ -0,0 +1,244 @@
+package domain
+
+import "sort"
+
+// Reasons for cards still held by a loser when the game ends ("thoi heo / thoi bom").
+const (
+	PenaltyBlackPig  = "Black Pig" // Leftover 2 of Spades or Clubs
+	PenaltyRedPig    = "Red Pig"   // Leftover 2 of Diamonds or Hearts
+	PenaltyQuad      = "Quad"      // Leftover four of a kind
+	PenaltyThreePine = "3-Pine"    // Leftover 3 consecutive pairs
+	PenaltyFourPine  = "4-Pine"    // Leftover 4 consecutive pairs
+	PenaltyFivePine  = "5-Pine"    // Leftover 5 or more consecutive pairs
+	PenaltyCong      = "Cong"      // Frozen player's top-up to the cong multiplier
+	PenaltyStakeCap  = "Stake Cap" // Correction when a loser's stake could not cover what they owed; seat -1 is the escrow
+)
+
+// LeftoverPenaltyRates holds the BaseBet multiplier charged for each leftover 2 or bomb.
+// A zero rate disables that penalty.
+type LeftoverPenaltyRates struct {
+	BlackPig  int64
+	RedPig    int64
+	Quad      int64
+	ThreePine int64
+	FourPine  int64
+	FivePine  int64
+}
+
+// DefaultLeftoverPenaltyRates mirrors the default chop multipliers used during play.
+func DefaultLeftoverPenaltyRates() LeftoverPenaltyRates {
+	return LeftoverPenaltyRates{
+		BlackPig:  1,
+		RedPig:    2,
+		Quad:      4,
+		ThreePine: 3,
+		FourPine:  5,
+		FivePine:  10,
+	}
+}
+
+func (r LeftoverPenaltyRates) rate(reason string) int64 {
+	switch reason {
+	case PenaltyBlackPig:
+		return r.BlackPig
+	case PenaltyRedPig:
+		return r.RedPig
+	case PenaltyQuad:
+		return r.Quad
+	case PenaltyThreePine:
+		return r.ThreePine
+	case PenaltyFourPine:
+		return r.FourPine
+	case PenaltyFivePine:
+		return r.FivePine
+	}
+	return 0
+}
+
+// PenaltyItem is a single settlement line item charged from one player to another.
+type PenaltyItem struct {
+	PayerSeat int
+	PayeeSeat int
+	Reason    string
+	Cards     []Card
+	Amount    int64
+}
+
+// FindLeftoverPenalties splits a hand into the groups that are charged at game end.
+// Each 2 is charged on its own; quads are taken before consecutive pairs so no card counts twice, and
+// consecutive pairs only count when rules have them. The returned items only carry Reason and Cards.
+func FindLeftoverPenalties(hand []Card, rules RuleSet) []PenaltyItem {
+	var items []PenaltyItem
+	var byRank [13][]Card
+	for _, c := range hand {
+		if c.Rank < 0 || c.Rank > 12 {
+			continue
+		}
+		byRank[c.Rank] = append(byRank[c.Rank], c)
+	}
+
+	// Pigs (2s)
+	for _, c := range byRank[12] {
+		reason := PenaltyBlackPig
+		if c.Suit >= 2 {
+			reason = PenaltyRedPig
+		}
+		items = append(items, PenaltyItem{Reason: reason, Cards: []Card{c}})
+	}
+
+	// Quads (2s excluded, already charged as pigs)
+	for r := 0; r < 12; r++ {
+		if len(byRank[r]) == 4 {
+			quad := append([]Card{}, byRank[r]...)
+			SortHand(quad)
+			items = append(items, PenaltyItem{Reason: PenaltyQuad, Cards: quad})
+			byRank[r] = nil
+		}
+	}
+
+	if !rules.HasConsecutivePairs() {
+		return items
+	}
+
+	// Consecutive pairs (pines): runs of 3+ ranks holding at least a pair, 2s excluded.
+	runStart := -1
+	for r := 0; r <= 12; r++ {
+		if r < 12 && len(byRank[r]) >= 2 {
+			if runStart < 0 {
+				runStart = r
+			}
+			continue
+		}
+		if runStart >= 0 {
+			if length := r - runStart; length >= 3 {
+				items = append(items, pineItem(byRank[runStart:r], length))
+			}
+			runStart = -1
+		}
+	}
+
+	return items
+}
+
+func pineItem(ranks [][]Card, length int) PenaltyItem {
+	reason := PenaltyFivePine
+	switch length {
+	case 3:
+		reason = PenaltyThreePine
+	case 4:
+		reason = PenaltyFourPine
+	}
+
+	cards := make([]Card, 0, length*2)
+	for _, group := range ranks {
+		pair := append([]Card{}, group...)
+		SortHand(pair)
+		cards = append(cards, pair[len(pair)-2:]...) // Keep the two highest suits
+	}
+	return PenaltyItem{Reason: reason, Cards: cards}
+}
+
+// leftoverPenalties charges every loser still holding 2s or bombs, paid to the winner.
+func (g *Game) leftoverPenalties() []PenaltyItem {
+	if len(g.FinishOrderSeats) == 0 {
+		return nil
+	}
+	winnerSeat := g.FinishOrderSeats[0]
+
+	losers := make([]*Player, 0, len(g.Players))
+	for _, p := range g.Players {
+		if p.Seat != winnerSeat && len(p.Hand) > 0 {
+			losers = append(losers, p)
+		}
+	}
+	sort.Slice(losers, func(i, j int) bool { return losers[i].Seat < losers[j].Seat })
+
+	var items []PenaltyItem
+	for _, p := range losers {
+		for _, item := range FindLeftoverPenalties(p.Hand, g.RuleSet()) {
+			rate := g.LeftoverPenalties.rate(item.Reason)
+			if rate <= 0 {
+				continue
+			}
+			item.PayerSeat = p.Seat
+			item.PayeeSeat = winnerSeat
+			item.Amount = rate * g.BaseBet
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ChopPenaltyTable holds the BaseBet multiplier paid by the owner of a chopped combination.
+// Pairs of 2s are priced by colour composition.
+type ChopPenaltyTable struct {
+	BlackPig        int64
+	RedPig          int64
+	BlackPairOfTwos int64 // Spades + Clubs
+	MixedPairOfTwos int64 // One black, one red
+	RedPairOfTwos   int64 // Diamonds + Hearts
+	ThreePine       int64
+	Quad            int64
+	FourPine        int64
+	FivePine        int64
+}
+
+// DefaultChopPenaltyTable is used when no table is configured.
+func DefaultChopPenaltyTable() ChopPenaltyTable {
+	return ChopPenaltyTable{
+		BlackPig:        1,
+		RedPig:          2,
+		BlackPairOfTwos: 2,
+		MixedPairOfTwos: 3,
+		RedPairOfTwos:   4,
+		ThreePine:       3,
+		Quad:            4,
+		FourPine:        5,
+		FivePine:        10,
+	}
+}
+
+// Multiplier prices a chopped combination. Combinations outside the table cost 1.
+func (t ChopPenaltyTable) Multiplier(victim CardCombination) int64 {
+	switch victim.Type {
+	case Single:
+		if len(victim.Cards) == 1 && victim.Cards[0].Rank == 12 {
+			if victim.Cards[0].Suit >= 2 {
+				return t.RedPig
+			}
+			return t.BlackPig
+		}
+	case Pair:
+		if len(victim.Cards) == 2 && victim.Cards[0].Rank == 12 {
+			switch redCount(victim.Cards) {
+			case 0:
+				return t.BlackPairOfTwos
+			case 1:
+				return t.MixedPairOfTwos
+			default:
+				return t.RedPairOfTwos
+			}
+		}
+	case Bomb:
+		switch victim.Count {
+		case 4:
+			return t.Quad
+		case 6:
+			return t.ThreePine
+		case 8:
+			return t.FourPine
+		case 10:
+			return t.FivePine
+		}
+	}
+	return 1
+}
+
+// ChopMultiplier prices a chopped combination with the game's table, or the default table if none was set.
+func (g *Game) ChopMultiplier(victim CardCombination) int64 {
+	table := g.ChopPenalties
+	if table == (ChopPenaltyTable{}) {
+		table = DefaultChopPenaltyTable()
+	}
+	return table.Multiplier(victim)
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestFindLeftoverPenalties(t *testing.T) {
+	tests := []struct {
+		name    string
+		hand    []Card
+		reasons []string
+	}{
+		{
+			name:    "No penalties",
+			hand:    []Card{{Rank: 0, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 11, Suit: 3}},
+			reasons: nil,
+		},
+		{
+			name:    "Black and red pigs",
+			hand:    []Card{{Rank: 12, Suit: 0}, {Rank: 12, Suit: 3}, {Rank: 4, Suit: 1}},
+			reasons: []string{PenaltyBlackPig, PenaltyRedPig},
+		},
+		{
+			name: "Quad",
+			hand: []Card{
+				{Rank: 7, Suit: 0}, {Rank: 7, Suit: 1}, {Rank: 7, Suit: 2}, {Rank: 7, Suit: 3},
+				{Rank: 2, Suit: 0},
+			},
+			reasons: []string{PenaltyQuad},
+		},
+		{
+			name: "3-Pine",
+			hand: []Card{
+				{Rank: 3, Suit: 0}, {Rank: 3, Suit: 1},
+				{Rank: 4, Suit: 0}, {Rank: 4, Suit: 2},
+				{Rank: 5, Suit: 1}, {Rank: 5, Suit: 3},
+			},
+			reasons: []string{PenaltyThreePine},
+		},
+		{
+			name: "4-Pine ending at Ace",
+			hand: []Card{
+				{Rank: 8, Suit: 0}, {Rank: 8, Suit: 1},
+				{Rank: 9, Suit: 0}, {Rank: 9, Suit: 2},
+				{Rank: 10, Suit: 1}, {Rank: 10, Suit: 3},
+				{Rank: 11, Suit: 1}, {Rank: 11, Suit: 3},
+			},
+			reasons: []string{PenaltyFourPine},
+		},
+		{
+			name: "Pairs of 2s do not extend a pine",
+			hand: []Card{
+				{Rank: 10, Suit: 0}, {Rank: 10, Suit: 1},
+				{Rank: 11, Suit: 0}, {Rank: 11, Suit: 2},
+				{Rank: 12, Suit: 1}, {Rank: 12, Suit: 2},
+			},
+			reasons: []string{PenaltyBlackPig, PenaltyRedPig},
+		},
+		{
+			name: "Quad rank is not reused in a pine",
+			hand: []Card{
+				{Rank: 3, Suit: 0}, {Rank: 3, Suit: 1},
+				{Rank: 4, Suit: 0}, {Rank: 4, Suit: 1}, {Rank: 4, Suit: 2}, {Rank: 4, Suit: 3},
+				{Rank: 5, Suit: 1}, {Rank: 5, Suit: 3},
+			},
+			reasons: []string{PenaltyQuad},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := FindLeftoverPenalties(tt.hand, SouthernRules{})
+			if len(items) != len(tt.reasons) {
+				t.Fatalf("got %d items %+v, want %v", len(items), items, tt.reasons)
+			}
+			for i, item := range items {
+				if item.Reason != tt.reasons[i] {
+					t.Errorf("item %d reason = %q, want %q", i, item.Reason, tt.reasons[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateSettlement_LeftoverPenalties(t *testing.T) {
+	game := &Game{
+		Players: map[string]*Player{
+			"u0": {UserID: "u0", Seat: 0, Finished: true},
+			"u1": {UserID: "u1", Seat: 1, Hand: []Card{
+				{Rank: 12, Suit: 0}, // Black pig
+				{Rank: 12, Suit: 3}, // Red pig
+				{Rank: 6, Suit: 0}, {Rank: 6, Suit: 1}, {Rank: 6, Suit: 2}, {Rank: 6, Suit: 3}, // Quad
+			}},
+		},
+		FinishOrderSeats:  []int{0, 1},
+		BaseBet:           100,
+		LeftoverPenalties: DefaultLeftoverPenaltyRates(),
+	}
+
+	settlement := game.CalculateSettlement()
+
+	// Rank payout (+/-100) plus 1 + 2 + 4 units of penalties.
+	if settlement.BalanceChanges["u0"] != 800 {
+		t.Errorf("winner change = %d, want 800", settlement.BalanceChanges["u0"])
+	}
+	if settlement.BalanceChanges["u1"] != -800 {
+		t.Errorf("loser change = %d, want -800", settlement.BalanceChanges["u1"])
+	}
+	if len(settlement.Penalties) != 3 {
+		t.Fatalf("got %d penalties, want 3", len(settlement.Penalties))
+	}
+	for _, item := range settlement.Penalties {
+		if item.PayerSeat != 1 || item.PayeeSeat != 0 {
+			t.Errorf("penalty %q paid %d -> %d, want 1 -> 0", item.Reason, item.PayerSeat, item.PayeeSeat)
+		}
+	}
+}
+
+func TestChopPenaltyTable_Multiplier(t *testing.T) {
+	table := DefaultChopPenaltyTable()
+	tests := []struct {
+		name   string
+		victim CardCombination
+		want   int64
+	}{
+		{"Black pig", CardCombination{Type: Single, Cards: []Card{{Rank: 12, Suit: 1}}, Count: 1}, table.BlackPig},
+		{"Red pig", CardCombination{Type: Single, Cards: []Card{{Rank: 12, Suit: 2}}, Count: 1}, table.RedPig},
+		{"Black pair of 2s", CardCombination{Type: Pair, Cards: []Card{{Rank: 12, Suit: 0}, {Rank: 12, Suit: 1}}, Count: 2}, table.BlackPairOfTwos},
+		{"Mixed pair of 2s", CardCombination{Type: Pair, Cards: []Card{{Rank: 12, Suit: 0}, {Rank: 12, Suit: 3}}, Count: 2}, table.MixedPairOfTwos},
+		{"Red pair of 2s", CardCombination{Type: Pair, Cards: []Card{{Rank: 12, Suit: 2}, {Rank: 12, Suit: 3}}, Count: 2}, table.RedPairOfTwos},
+		{"Quad", CardCombination{Type: Bomb, Count: 4}, table.Quad},
+		{"4-Pine", CardCombination{Type: Bomb, Count: 8}, table.FourPine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Multiplier(tt.victim); got != tt.want {
+				t.Errorf("Multiplier() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// A game without a table falls back to the defaults.
+	game := &Game{}
+	if got := game.ChopMultiplier(tests[1].victim); got != table.RedPig {
+		t.Errorf("Game.ChopMultiplier() = %d, want default %d", got, table.RedPig)
+	}
+}
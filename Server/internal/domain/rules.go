@@ -1,7 +1,5 @@
 package domain
 
-import "sort"
-
 // CardCombinationType represents the type of card combination.
 type CardCombinationType int
 
@@ -24,157 +22,122 @@ type CardCombination struct {
 }
 
 // IsValidSet checks if the cards form a legal Tien Len combination.
+// Duplicate cards or cards outside the deck never form a combination.
 func IsValidSet(cards []Card) bool {
-	if len(cards) == 0 {
-		return false
-	}
-	if len(cards) == 1 {
-		return true
-	}
-
-	// Same-rank sets: pair, triple, quad (bomb)
-	if allSameRank(cards) {
-		return len(cards) <= 4
-	}
-
-	// Straights (sanh): length >= 3, ranks consecutive, cannot contain 2 (rank 12), no duplicates.
-	if isStraight(cards) {
-		return true
-	}
-
-	// Consecutive pairs (doi thong): even length >= 6, pairs of same rank, ranks consecutive, cannot contain 2.
-	if isConsecutivePairs(cards) {
-		return true
-	}
-
-	return false
+	set := NewCardSet(cards)
+	return len(cards) > 0 && set.Len() == len(cards) && set.CombinationType() != Invalid
 }
 
 // DetectChop checks if the new move is a "Chop" (Bomb/Pine capturing a high-value target)
 // and returns true plus the name of the chop type (e.g., "3-Pine", "Quad").
 func DetectChop(prevCards, newCards []Card) (bool, string) {
-	if !CanBeat(prevCards, newCards) {
+	return DetectChopSet(NewCardSet(prevCards), NewCardSet(newCards))
+}
+
+// DetectChopSet is DetectChop for card sets.
+func DetectChopSet(prev, next CardSet) (bool, string) {
+	if !CanBeatSet(prev, next) {
 		return false, ""
 	}
 
 	// Identify new types
-	isNew3Pine := isThreeConsecutivePairs(newCards)
-	isNew4Pine := isFourConsecutivePairs(newCards)
-	isNew5Pine := isFiveConsecutivePairs(newCards)
-	isNewQuad := isQuad(newCards)
+	newPairs := next.ConsecutivePairs()
+	isNewQuad := next.IsQuad()
 
 	// Identify prev types
-	isPrevSingle2 := len(prevCards) == 1 && prevCards[0].Rank == 12
-	isPrevPair2 := len(prevCards) == 2 && allSameRank(prevCards) && prevCards[0].Rank == 12
-	isPrev3Pine := isThreeConsecutivePairs(prevCards)
-	isPrev4Pine := isFourConsecutivePairs(prevCards)
-	isPrevQuad := isQuad(prevCards)
-
-	// 3-Pine chops Single 2
-	if isNew3Pine {
-		if isPrevSingle2 {
-			return true, "3-Pine"
-		}
-		if isPrev3Pine {
-			// Overchop
-			return true, "3-Pine"
-		}
-	}
-
-	// Quad chops Single 2, Pair 2, or 3-Pine
-	if isNewQuad {
-		if isPrevSingle2 || isPrevPair2 || isPrev3Pine {
-			return true, "Quad"
-		}
-		if isPrevQuad {
-			// Overchop
-			return true, "Quad"
-		}
-	}
-
-	// 4-Pine chops Single 2, Pair 2, Quad, or 3-Pine
-	if isNew4Pine {
-		if isPrevSingle2 || isPrevPair2 || isPrevQuad || isPrev3Pine {
-			return true, "4-Pine"
-		}
-		if isPrev4Pine {
-			// Overchop
-			return true, "4-Pine"
-		}
-	}
-
+	isPrevTwo := isSingleTwo(prev) || isPairOfTwos(prev)
+	prevPairs := prev.ConsecutivePairs()
+	isPrevQuad := prev.IsQuad()
+
+	switch {
+	// 3-Pine chops Single 2, or overchops a 3-Pine
+	case newPairs == 3 && (isSingleTwo(prev) || prevPairs == 3):
+		return true, "3-Pine"
+	// Quad chops Single 2, Pair 2, or 3-Pine, or overchops a Quad
+	case isNewQuad && (isPrevTwo || prevPairs == 3 || isPrevQuad):
+		return true, "Quad"
+	// 4-Pine chops Single 2, Pair 2, Quad, or 3-Pine, or overchops a 4-Pine
+	case newPairs == 4 && (isPrevTwo || isPrevQuad || prevPairs == 3 || prevPairs == 4):
+		return true, "4-Pine"
 	// 5-Pine chops everything
-	if isNew5Pine {
+	case newPairs == 5:
 		return true, "5-Pine"
 	}
-
 	return false, ""
 }
 
 // CanBeat determines if newCards can beat prevCards according to Tien Len rules.
 // Includes full "Pig Chopping" logic for Quads and Consecutive Pairs (Pine/Thong).
 func CanBeat(prevCards, newCards []Card) bool {
+	return CanBeatSet(NewCardSet(prevCards), NewCardSet(newCards))
+}
+
+// CanBeatSet is CanBeat for card sets.
+func CanBeatSet(prev, next CardSet) bool {
+	// Only quads and runs of consecutive pairs chop; skip the bomb checks for any other size.
+	if n := next.Len(); n != 4 && (n < 6 || n%2 != 0) {
+		return beatsByPower(prev, next)
+	}
+
 	// Identify types for chopping logic
-	isNewQuad := isQuad(newCards)
-	isNew3Pine := isThreeConsecutivePairs(newCards)
-	isNew4Pine := isFourConsecutivePairs(newCards)
-	isNew5Pine := isFiveConsecutivePairs(newCards)
+	isNewQuad := next.IsQuad()
+	newPairs := next.ConsecutivePairs()
 
 	// Identify prev types
-	isPrevSingle2 := len(prevCards) == 1 && prevCards[0].Rank == 12
-	isPrevPair2 := len(prevCards) == 2 && allSameRank(prevCards) && prevCards[0].Rank == 12
-	isPrevQuad := isQuad(prevCards)
-	isPrev3Pine := isThreeConsecutivePairs(prevCards)
-	isPrev4Pine := isFourConsecutivePairs(prevCards)
-	isPrev5Pine := isFiveConsecutivePairs(prevCards)
+	isPrevSingle2 := isSingleTwo(prev)
+	isPrevPair2 := isPairOfTwos(prev)
+	isPrevQuad := prev.IsQuad()
+	prevPairs := prev.ConsecutivePairs()
 
+	switch {
 	// --- 5 Pairs of Consecutive Sequence (5-Pine) ---
-	// Beats: Single 2, Pair 2, Quad, 4-Pine, Smaller 5-Pine
-	if isNew5Pine {
-		if isPrevSingle2 || isPrevPair2 || isPrevQuad || isPrev4Pine || isPrev3Pine {
+	// Beats: Single 2, Pair 2, Quad, 3-Pine, 4-Pine, Smaller 5-Pine
+	case newPairs == 5:
+		if isPrevSingle2 || isPrevPair2 || isPrevQuad || prevPairs == 3 || prevPairs == 4 {
 			return true
 		}
-		if isPrev5Pine {
-			return getMaxPower(newCards) > getMaxPower(prevCards)
+		if prevPairs == 5 {
+			return next.maxPower() > prev.maxPower()
 		}
-	}
 
 	// --- 4 Pairs of Consecutive Sequence (4-Pine) ---
-	// Beats: Single 2, Pair 2, Quad, Smaller 4-Pine
-	if isNew4Pine {
-		if isPrevSingle2 || isPrevPair2 || isPrevQuad || isPrev3Pine {
+	// Beats: Single 2, Pair 2, Quad, 3-Pine, Smaller 4-Pine
+	case newPairs == 4:
+		if isPrevSingle2 || isPrevPair2 || isPrevQuad || prevPairs == 3 {
 			return true
 		}
-		if isPrev4Pine {
-			return getMaxPower(newCards) > getMaxPower(prevCards)
+		if prevPairs == 4 {
+			return next.maxPower() > prev.maxPower()
 		}
-	}
 
 	// --- Quad (Four of a Kind) ---
 	// Beats: Single 2, Pair 2, Smaller Quad, 3-Pine
-	if isNewQuad {
-		if isPrevSingle2 || isPrevPair2 || isPrev3Pine {
+	case isNewQuad:
+		if isPrevSingle2 || isPrevPair2 || prevPairs == 3 {
 			return true
 		}
 		if isPrevQuad {
-			return newCards[0].Rank > prevCards[0].Rank
+			return next.maxPower() > prev.maxPower()
 		}
-	}
 
 	// --- 3 Pairs of Consecutive Sequence (3-Pine) ---
 	// Beats: Single 2, Smaller 3-Pine
-	if isNew3Pine {
+	case newPairs == 3:
 		if isPrevSingle2 {
 			return true
 		}
-		if isPrev3Pine {
-			return getMaxPower(newCards) > getMaxPower(prevCards)
+		if prevPairs == 3 {
+			return next.maxPower() > prev.maxPower()
 		}
 	}
 
-	// --- Standard Rules ---
+	return beatsByPower(prev, next)
+}
+
+// beatsByPower applies the standard rules when no chop is involved.
+func beatsByPower(prev, next CardSet) bool {
 	// 1. Must be same length
-	if len(prevCards) != len(newCards) {
+	if prev.Len() != next.Len() {
 		return false
 	}
 
@@ -183,7 +146,7 @@ func CanBeat(prevCards, newCards []Card) bool {
 	// but we implicitly rely on structure similarity here).
 
 	// 3. Compare highest card power
-	return getMaxPower(newCards) > getMaxPower(prevCards)
+	return next.maxPower() > prev.maxPower()
 }
 
 // IdentifyCombination analyzes a set of cards and returns the strongest valid Tien Len combination.
@@ -191,128 +154,24 @@ func IdentifyCombination(cards []Card) CardCombination {
 	if !IsValidSet(cards) {
 		return CardCombination{Type: Invalid}
 	}
-
-	SortHand(cards)
-	n := len(cards)
-
-	if n == 1 {
-		return CardCombination{Type: Single, Cards: cards, Value: CardPower(cards[0]), Count: 1}
-	}
-
-	if allSameRank(cards) {
-		val := CardPower(cards[n-1])
-		switch n {
-		case 2:
-			return CardCombination{Type: Pair, Cards: cards, Value: val, Count: 2}
-		case 3:
-			return CardCombination{Type: Triple, Cards: cards, Value: val, Count: 3}
-		case 4:
-			return CardCombination{Type: Bomb, Cards: cards, Value: val, Count: 4}
-		}
-	}
-
-	if isStraight(cards) {
-		return CardCombination{Type: Straight, Cards: cards, Value: CardPower(cards[n-1]), Count: n}
-	}
-
-	if isConsecutivePairs(cards) {
-		return CardCombination{Type: Bomb, Cards: cards, Value: CardPower(cards[n-1]), Count: n}
-	}
-
-	return CardCombination{Type: Invalid}
-}
-
-func isQuad(cards []Card) bool {
-	return len(cards) == 4 && allSameRank(cards)
-}
-
-func isThreeConsecutivePairs(cards []Card) bool {
-	return len(cards) == 6 && isConsecutivePairs(cards)
+	return classifyCombination(cards)
 }
 
-func isFourConsecutivePairs(cards []Card) bool {
-	return len(cards) == 8 && isConsecutivePairs(cards)
-}
-
-func isFiveConsecutivePairs(cards []Card) bool {
-	return len(cards) == 10 && isConsecutivePairs(cards)
-}
-
-func getMaxPower(cards []Card) int32 {
-	maxP := int32(-1)
-	for _, c := range cards {
-		p := CardPower(c)
-		if p > maxP {
-			maxP = p
-		}
-	}
-	return maxP
-}
-
-func allSameRank(cards []Card) bool {
-	if len(cards) == 0 {
-		return false
-	}
-	r := cards[0].Rank
-	for _, c := range cards {
-		if c.Rank != r {
-			return false
-		}
+// classifyCombination sorts cards and determines the combination type of an already validated set.
+func classifyCombination(cards []Card) CardCombination {
+	SortHand(cards)
+	set := NewCardSet(cards)
+	kind := set.CombinationType()
+	if kind == Invalid {
+		return CardCombination{Type: Invalid}
 	}
-	return true
+	return CardCombination{Type: kind, Cards: cards, Value: set.maxPower(), Count: len(cards)}
 }
 
-func isStraight(cards []Card) bool {
-	if len(cards) < 3 {
-		return false
-	}
-	ranks := make([]int32, len(cards))
-	for i, c := range cards {
-		if c.Rank == 12 { // 2 cannot be in a straight
-			return false
-		}
-		ranks[i] = c.Rank
-	}
-	sort.Slice(ranks, func(i, j int) bool { return ranks[i] < ranks[j] })
-
-	for i := 1; i < len(ranks); i++ {
-		if ranks[i] == ranks[i-1] {
-			return false // duplicate rank not allowed
-		}
-		if ranks[i] != ranks[i-1]+1 {
-			return false // not consecutive
-		}
-	}
-	return true
+func isSingleTwo(s CardSet) bool {
+	return s.Len() == 1 && s&twosMask != 0
 }
 
-func isConsecutivePairs(cards []Card) bool {
-	if len(cards) < 6 || len(cards)%2 != 0 {
-		return false
-	}
-	ranks := make([]int32, len(cards))
-	for i, c := range cards {
-		if c.Rank == 12 { // 2 cannot be part of consecutive pairs
-			return false
-		}
-		ranks[i] = c.Rank
-	}
-	sort.Slice(ranks, func(i, j int) bool { return ranks[i] < ranks[j] })
-
-	// Check that cards are grouped in pairs of the same rank.
-	pairRanks := make([]int32, 0, len(ranks)/2)
-	for i := 0; i < len(ranks); i += 2 {
-		if ranks[i] != ranks[i+1] {
-			return false
-		}
-		pairRanks = append(pairRanks, ranks[i])
-	}
-
-	// Check consecutive ranks across pairs.
-	for i := 1; i < len(pairRanks); i++ {
-		if pairRanks[i] != pairRanks[i-1]+1 {
-			return false
-		}
-	}
-	return true
+func isPairOfTwos(s CardSet) bool {
+	return s.Len() == 2 && s&twosMask == s
 }
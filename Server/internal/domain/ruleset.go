@@ -0,0 +1,152 @@
+package domain
+
+import "errors"
+
+// Rule set names accepted in match params.
+const (
+	RuleSetSouthern = "southern" // Tien Len mien Nam (default)
+	RuleSetNorthern = "northern" // Tien Len mien Bac
+)
+
+// ErrUnknownRuleSet is returned when a rule set name is not recognized.
+var ErrUnknownRuleSet = errors.New("unknown rule set")
+
+// RuleSet captures the regional rules used to validate and compare plays.
+type RuleSet interface {
+	// Name returns the identifier used in match params (e.g. "southern").
+	Name() string
+	// IsValidSet checks if the cards form a legal combination under this rule set.
+	IsValidSet(cards []Card) bool
+	// IdentifyCombination classifies the cards, returning Invalid when the rule set rejects them.
+	IdentifyCombination(cards []Card) CardCombination
+	// CanBeat determines if newCards can be played on top of prevCards.
+	CanBeat(prevCards, newCards []Card) bool
+	// DetectChop reports whether newCards chops prevCards and the name of the chop type.
+	// Chops are priced by the game's ChopPenaltyTable.
+	DetectChop(prevCards, newCards []Card) (bool, string)
+	// HasConsecutivePairs reports whether runs of consecutive pairs ("pines") are a combination. Without
+	// them they are neither charged as leftovers nor an instant win.
+	HasConsecutivePairs() bool
+	// Options returns the house-rule toggles enabled for this rule set.
+	Options() RuleOptions
+}
+
+// RuleOptions are house-rule toggles shared by every rule set. Rule sets embed them.
+type RuleOptions struct {
+	// OpeningCard requires the first play of a fresh table to include the 3 of Spades,
+	// or the lowest card dealt when fewer than four players sit.
+	OpeningCard bool
+}
+
+// Options returns the embedded options.
+func (o RuleOptions) Options() RuleOptions { return o }
+
+// RuleSetByName returns the rule set registered under name with the given options.
+// An empty name selects Southern.
+func RuleSetByName(name string, opts RuleOptions) (RuleSet, error) {
+	switch name {
+	case "", RuleSetSouthern:
+		return SouthernRules{RuleOptions: opts}, nil
+	case RuleSetNorthern:
+		return NorthernRules{RuleOptions: opts}, nil
+	}
+	return nil, ErrUnknownRuleSet
+}
+
+// RuleSet returns the game's rule set, defaulting to Southern.
+func (g *Game) RuleSet() RuleSet {
+	if g == nil || g.Rules == nil {
+		return SouthernRules{}
+	}
+	return g.Rules
+}
+
+// SouthernRules implements Tien Len mien Nam: any-suit straights, consecutive pairs and chopping 2s.
+type SouthernRules struct {
+	RuleOptions
+}
+
+func (SouthernRules) Name() string { return RuleSetSouthern }
+
+func (SouthernRules) IsValidSet(cards []Card) bool { return IsValidSet(cards) }
+
+func (SouthernRules) IdentifyCombination(cards []Card) CardCombination {
+	return IdentifyCombination(cards)
+}
+
+func (SouthernRules) CanBeat(prevCards, newCards []Card) bool { return CanBeat(prevCards, newCards) }
+
+func (SouthernRules) DetectChop(prevCards, newCards []Card) (bool, string) {
+	return DetectChop(prevCards, newCards)
+}
+
+func (SouthernRules) HasConsecutivePairs() bool { return true }
+
+// NorthernRules implements Tien Len mien Bac: plays must follow suit (singles, straights)
+// or colour (pairs), straights are a single suit, there are no consecutive pairs,
+// and 2s can only be beaten by higher 2s, so nothing is ever chopped.
+type NorthernRules struct {
+	RuleOptions
+}
+
+func (NorthernRules) Name() string { return RuleSetNorthern }
+
+func (NorthernRules) IsValidSet(cards []Card) bool {
+	set := NewCardSet(cards)
+	if len(cards) == 0 || set.Len() != len(cards) {
+		return false
+	}
+	return set.Len() == 1 || set.SameRank() || (set.IsStraight() && set.SameSuit())
+}
+
+func (n NorthernRules) IdentifyCombination(cards []Card) CardCombination {
+	if !n.IsValidSet(cards) {
+		return CardCombination{Type: Invalid}
+	}
+	return classifyCombination(cards)
+}
+
+func (n NorthernRules) CanBeat(prevCards, newCards []Card) bool {
+	prev := n.IdentifyCombination(append([]Card(nil), prevCards...))
+	next := n.IdentifyCombination(append([]Card(nil), newCards...))
+	if prev.Type == Invalid || next.Type != prev.Type || next.Count != prev.Count {
+		return false
+	}
+
+	switch next.Type {
+	case Single:
+		p, c := prev.Cards[0], next.Cards[0]
+		if p.Rank == 12 || c.Rank == 12 {
+			// A 2 beats any other single; only a higher 2 beats a 2.
+			return c.Rank == 12 && next.Value > prev.Value
+		}
+		return c.Suit == p.Suit && c.Rank > p.Rank
+	case Pair:
+		// Pairs follow colour, except a pair of 2s which may be played on any pair.
+		if next.Cards[0].Rank != 12 && redCount(next.Cards) != redCount(prev.Cards) {
+			return false
+		}
+	case Straight:
+		if next.Cards[0].Suit != prev.Cards[0].Suit {
+			return false
+		}
+	}
+	return next.Value > prev.Value
+}
+
+func (NorthernRules) DetectChop(prevCards, newCards []Card) (bool, string) {
+	return false, ""
+}
+
+func (NorthernRules) HasConsecutivePairs() bool { return false }
+
+// redCount returns how many cards are Diamonds or Hearts.
+func redCount(cards []Card) int {
+	n := 0
+	for _, c := range cards {
+		if c.Suit >= 2 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestRuleSetByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: RuleSetSouthern},
+		{name: RuleSetSouthern, want: RuleSetSouthern},
+		{name: RuleSetNorthern, want: RuleSetNorthern},
+		{name: "western", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		rules, err := RuleSetByName(tt.name, RuleOptions{})
+		if tt.wantErr {
+			if err != ErrUnknownRuleSet {
+				t.Errorf("RuleSetByName(%q) error = %v, want ErrUnknownRuleSet", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || rules.Name() != tt.want {
+			t.Errorf("RuleSetByName(%q) = %v, %v; want %s", tt.name, rules, err, tt.want)
+		}
+	}
+
+	rules, _ := RuleSetByName(RuleSetNorthern, RuleOptions{OpeningCard: true})
+	if !rules.Options().OpeningCard {
+		t.Error("expected options to be carried by the rule set")
+	}
+}
+
+func TestNorthernRules_IsValidSet(t *testing.T) {
+	rules := NorthernRules{}
+	tests := []struct {
+		name     string
+		cards    []Card
+		expected bool
+	}{
+		{"Single", []Card{{Rank: 0, Suit: 0}}, true},
+		{"Pair", []Card{{Rank: 5, Suit: 0}, {Rank: 5, Suit: 3}}, true},
+		{"Quad", []Card{{Rank: 5, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 5, Suit: 2}, {Rank: 5, Suit: 3}}, true},
+		{"Suited straight", []Card{{Rank: 0, Suit: 1}, {Rank: 1, Suit: 1}, {Rank: 2, Suit: 1}}, true},
+		{"Mixed straight", []Card{{Rank: 0, Suit: 1}, {Rank: 1, Suit: 2}, {Rank: 2, Suit: 1}}, false},
+		{"Consecutive pairs", []Card{
+			{Rank: 0, Suit: 0}, {Rank: 0, Suit: 1},
+			{Rank: 1, Suit: 0}, {Rank: 1, Suit: 1},
+			{Rank: 2, Suit: 0}, {Rank: 2, Suit: 1},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.IsValidSet(tt.cards); got != tt.expected {
+				t.Errorf("IsValidSet() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNorthernRules_CanBeat(t *testing.T) {
+	rules := NorthernRules{}
+	tests := []struct {
+		name     string
+		prev     []Card
+		new      []Card
+		expected bool
+	}{
+		{"Single follows suit", []Card{{Rank: 3, Suit: 1}}, []Card{{Rank: 5, Suit: 1}}, true},
+		{"Single off suit", []Card{{Rank: 3, Suit: 1}}, []Card{{Rank: 5, Suit: 2}}, false},
+		{"2 beats any single", []Card{{Rank: 11, Suit: 3}}, []Card{{Rank: 12, Suit: 0}}, true},
+		{"Higher 2 beats 2", []Card{{Rank: 12, Suit: 0}}, []Card{{Rank: 12, Suit: 3}}, true},
+		{"Quad cannot chop 2", []Card{{Rank: 12, Suit: 3}}, []Card{{Rank: 5, Suit: 0}, {Rank: 5, Suit: 1}, {Rank: 5, Suit: 2}, {Rank: 5, Suit: 3}}, false},
+		{"Pair follows colour", []Card{{Rank: 3, Suit: 0}, {Rank: 3, Suit: 1}}, []Card{{Rank: 6, Suit: 0}, {Rank: 6, Suit: 1}}, true},
+		{"Pair wrong colour", []Card{{Rank: 3, Suit: 0}, {Rank: 3, Suit: 1}}, []Card{{Rank: 6, Suit: 2}, {Rank: 6, Suit: 3}}, false},
+		{"Pair of 2s beats any pair", []Card{{Rank: 11, Suit: 2}, {Rank: 11, Suit: 3}}, []Card{{Rank: 12, Suit: 0}, {Rank: 12, Suit: 1}}, true},
+		{"Straight follows suit", []Card{{Rank: 0, Suit: 2}, {Rank: 1, Suit: 2}, {Rank: 2, Suit: 2}}, []Card{{Rank: 1, Suit: 2}, {Rank: 2, Suit: 2}, {Rank: 3, Suit: 2}}, true},
+		{"Straight off suit", []Card{{Rank: 0, Suit: 2}, {Rank: 1, Suit: 2}, {Rank: 2, Suit: 2}}, []Card{{Rank: 4, Suit: 3}, {Rank: 5, Suit: 3}, {Rank: 6, Suit: 3}}, false},
+		{"Higher suited straight", []Card{{Rank: 0, Suit: 3}, {Rank: 1, Suit: 3}, {Rank: 2, Suit: 3}}, []Card{{Rank: 4, Suit: 3}, {Rank: 5, Suit: 3}, {Rank: 6, Suit: 3}}, true},
+		{"Triple cannot beat straight", []Card{{Rank: 0, Suit: 3}, {Rank: 1, Suit: 3}, {Rank: 2, Suit: 3}}, []Card{{Rank: 9, Suit: 0}, {Rank: 9, Suit: 1}, {Rank: 9, Suit: 2}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.CanBeat(tt.prev, tt.new); got != tt.expected {
+				t.Errorf("CanBeat() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNorthernRules_NoConsecutivePairPenaltiesOrInstantWin(t *testing.T) {
+	hand, err := ParseCards("3S 3C 4S 4C 5S 5C 6S 6C 7S 7C 9H JD KH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, winType := DetectInstantWin(hand, SouthernRules{}); !ok || winType != InstantWinFiveConsecutivePairs {
+		t.Fatalf("Southern DetectInstantWin() = (%v, %q), want five consecutive pairs", ok, winType)
+	}
+	if ok, winType := DetectInstantWin(hand, NorthernRules{}); ok {
+		t.Errorf("Northern DetectInstantWin() = %q, want no instant win without consecutive pairs", winType)
+	}
+
+	if items := FindLeftoverPenalties(hand, SouthernRules{}); len(items) != 1 || items[0].Reason != PenaltyFivePine {
+		t.Fatalf("Southern FindLeftoverPenalties() = %+v, want one 5-pine", items)
+	}
+	if items := FindLeftoverPenalties(hand, NorthernRules{}); len(items) != 0 {
+		t.Errorf("Northern FindLeftoverPenalties() = %+v, want none", items)
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+// Settlement policy names used in game config.
+const (
+	SettlementRankMatrix     = "rank_matrix"      // Fixed multiplier per finishing rank
+	SettlementWinnerTakesAll = "winner_takes_all" // Every loser pays the winner the same stake
+	SettlementCardCount      = "card_count"       // Every loser pays the winner per card left in hand
+)
+
+// SettlementPolicy decides how much each player wins or loses at the end of a game.
+type SettlementPolicy interface {
+	// Name returns the policy type (e.g. "rank_matrix").
+	Name() string
+	// Payouts returns the gold change per seat for the full finish order, before penalties and tax.
+	Payouts(g *Game, rankOrder []int) map[int]int64
+	// MaxLoss returns the most Payouts can charge the player in seat, whatever the finish order.
+	MaxLoss(g *Game, seat int) int64
+	// ApplyTax withholds the house cut from positive balance changes in place.
+	ApplyTax(changes map[string]int64)
+}
+
+// SettlementPolicy returns the game's settlement policy, defaulting to DefaultSettlementPolicy().
+func (g *Game) SettlementPolicy() SettlementPolicy {
+	if g == nil || g.Policy == nil {
+		return DefaultSettlementPolicy()
+	}
+	return g.Policy
+}
+
+// DefaultSettlementPolicy is the standard rank matrix without tax.
+func DefaultSettlementPolicy() SettlementPolicy {
+	return RankMatrixPolicy{Matrix: DefaultRankMatrix()}
+}
+
+// Tax withholds a fraction of every positive balance change. Policies embed it.
+type Tax struct {
+	Rate float64
+}
+
+// ApplyTax rounds each taxed winning down to whole gold.
+func (t Tax) ApplyTax(changes map[string]int64) {
+	if t.Rate <= 0 {
+		return
+	}
+	for uid, amount := range changes {
+		if amount > 0 {
+			changes[uid] = int64(float64(amount) * (1.0 - t.Rate))
+		}
+	}
+}
+
+// DefaultRankMatrix returns the standard BaseBet multipliers per finishing rank, keyed by player count:
+// 4 Players: 1st(+2), 2nd(+1), 3rd(-1), 4th(-2)
+// 3 Players: 1st(+3), 2nd(-1), 3rd(-2)
+// 2 Players: 1st(+1), 2nd(-1)
+func DefaultRankMatrix() map[int][]int64 {
+	return map[int][]int64{
+		4: {2, 1, -1, -2},
+		3: {3, -1, -2},
+		2: {1, -1},
+	}
+}
+
+// RankMatrixPolicy pays a fixed BaseBet multiplier per finishing rank.
+// Player counts missing from the matrix settle to zero.
+type RankMatrixPolicy struct {
+	Matrix map[int][]int64
+	Tax
+}
+
+func (RankMatrixPolicy) Name() string { return SettlementRankMatrix }
+
+func (p RankMatrixPolicy) Payouts(g *Game, rankOrder []int) map[int]int64 {
+	payouts := make(map[int]int64, len(rankOrder))
+	multipliers := p.Matrix[len(g.Players)]
+	for rank, seat := range rankOrder {
+		if rank >= len(multipliers) {
+			break
+		}
+		payouts[seat] = multipliers[rank] * g.BaseBet
+	}
+	return payouts
+}
+
+func (p RankMatrixPolicy) MaxLoss(g *Game, seat int) int64 {
+	var worst int64
+	for _, multiplier := range p.Matrix[len(g.Players)] {
+		if -multiplier > worst {
+			worst = -multiplier
+		}
+	}
+	return worst * g.BaseBet
+}
+
+// WinnerTakesAllPolicy charges every loser LoserMultiplier * BaseBet, all paid to the winner.
+type WinnerTakesAllPolicy struct {
+	LoserMultiplier int64 // Defaults to 1
+	Tax
+}
+
+func (WinnerTakesAllPolicy) Name() string { return SettlementWinnerTakesAll }
+
+func (p WinnerTakesAllPolicy) Payouts(g *Game, rankOrder []int) map[int]int64 {
+	multiplier := p.LoserMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	return payWinner(rankOrder, func(int) int64 { return multiplier * g.BaseBet })
+}
+
+func (p WinnerTakesAllPolicy) MaxLoss(g *Game, seat int) int64 {
+	multiplier := p.LoserMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	return multiplier * g.BaseBet
+}
+
+// CardCountPolicy charges every loser CardMultiplier * BaseBet per card left in hand, paid to the winner.
+type CardCountPolicy struct {
+	CardMultiplier int64 // Defaults to 1
+	Tax
+}
+
+func (CardCountPolicy) Name() string { return SettlementCardCount }
+
+func (p CardCountPolicy) Payouts(g *Game, rankOrder []int) map[int]int64 {
+	multiplier := p.CardMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	cards := make(map[int]int64, len(g.Players))
+	for _, pl := range g.Players {
+		cards[pl.Seat] = int64(len(pl.Hand))
+	}
+	return payWinner(rankOrder, func(seat int) int64 { return cards[seat] * multiplier * g.BaseBet })
+}
+
+// MaxLoss charges for the whole dealt hand, as if the player never played a card.
+func (p CardCountPolicy) MaxLoss(g *Game, seat int) int64 {
+	multiplier := p.CardMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	for _, pl := range g.Players {
+		if pl.Seat == seat {
+			return int64(len(pl.Hand)) * multiplier * g.BaseBet
+		}
+	}
+	return 0
+}
+
+// payWinner charges each loser its amount and credits the total to the first seat in rankOrder.
+func payWinner(rankOrder []int, owed func(seat int) int64) map[int]int64 {
+	payouts := make(map[int]int64, len(rankOrder))
+	if len(rankOrder) == 0 {
+		return payouts
+	}
+	var collected int64
+	for _, seat := range rankOrder[1:] {
+		amount := owed(seat)
+		payouts[seat] = -amount
+		collected += amount
+	}
+	payouts[rankOrder[0]] = collected
+	return payouts
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+)
+
+func newSettlementGame(policy SettlementPolicy) *Game {
+	return &Game{
+		Players: map[string]*Player{
+			"u0": {UserID: "u0", Seat: 0, Finished: true},
+			"u1": {UserID: "u1", Seat: 1, Finished: true},
+			"u2": {UserID: "u2", Seat: 2, Hand: []Card{{Rank: 0, Suit: 0}, {Rank: 1, Suit: 0}}},
+			"u3": {UserID: "u3", Seat: 3, Hand: []Card{{Rank: 2, Suit: 0}, {Rank: 3, Suit: 0}, {Rank: 4, Suit: 0}}},
+		},
+		FinishOrderSeats: []int{0, 1, 2, 3},
+		BaseBet:          100,
+		Policy:           policy,
+	}
+}
+
+func TestSettlementPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   SettlementPolicy
+		expected map[string]int64
+	}{
+		{
+			name:     "Default rank matrix",
+			policy:   nil,
+			expected: map[string]int64{"u0": 200, "u1": 100, "u2": -100, "u3": -200},
+		},
+		{
+			name:     "Only last place pays",
+			policy:   RankMatrixPolicy{Matrix: map[int][]int64{4: {1, 0, 0, -1}}},
+			expected: map[string]int64{"u0": 100, "u1": 0, "u2": 0, "u3": -100},
+		},
+		{
+			name:     "Winner takes all",
+			policy:   WinnerTakesAllPolicy{LoserMultiplier: 2},
+			expected: map[string]int64{"u0": 600, "u1": -200, "u2": -200, "u3": -200},
+		},
+		{
+			name:     "Card count",
+			policy:   CardCountPolicy{},
+			expected: map[string]int64{"u0": 500, "u1": 0, "u2": -200, "u3": -300},
+		},
+		{
+			name:     "Tax applies to winnings only",
+			policy:   WinnerTakesAllPolicy{Tax: Tax{Rate: 0.1}},
+			expected: map[string]int64{"u0": 270, "u1": -100, "u2": -100, "u3": -100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement := newSettlementGame(tt.policy).CalculateSettlement()
+			for uid, want := range tt.expected {
+				if got := settlement.BalanceChanges[uid]; got != want {
+					t.Errorf("%s change = %d, want %d", uid, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package domain
+
+// SeatView is the public state of one player: what everyone at the table can see.
+type SeatView struct {
+	Seat           int
+	UserID         string
+	CardsRemaining int
+	HasPassed      bool
+	Finished       bool
+	Frozen         bool
+}
+
+// PlayerView is a game as seen from one seat: the viewer's own hand plus public information only.
+// Opponents appear as card counts and flags, never as hands. A view for a seat without a player
+// (e.g. a spectator's) has no hand.
+type PlayerView struct {
+	Seat                  int    // Viewer's seat; -1 when the viewer is not playing
+	UserID                string // Viewer's user ID; empty when the viewer is not playing
+	Hand                  []Card // Copy of the viewer's hand
+	Seats                 []SeatView
+	Phase                 Phase
+	CurrentTurn           int
+	LastPlayedCombination CardCombination
+	LastPlayerToPlaySeat  int
+	FinishOrderSeats      []int
+	Discards              []Card
+	OpeningCard           *Card
+	Rules                 RuleSet
+}
+
+// View returns the game as seen by the player in seat. Slices are copied, so the view stays valid
+// while the game moves on. Pass -1 for a spectator's view.
+func (g *Game) View(seat int) PlayerView {
+	view := PlayerView{
+		Seat:                  -1,
+		Phase:                 g.Phase,
+		CurrentTurn:           g.CurrentTurn,
+		LastPlayedCombination: g.LastPlayedCombination,
+		LastPlayerToPlaySeat:  g.LastPlayerToPlaySeat,
+		FinishOrderSeats:      append([]int(nil), g.FinishOrderSeats...),
+		Discards:              append([]Card(nil), g.Discards...),
+		Rules:                 g.RuleSet(),
+	}
+	view.LastPlayedCombination.Cards = append([]Card(nil), g.LastPlayedCombination.Cards...)
+	if g.OpeningCard != nil {
+		opening := *g.OpeningCard
+		view.OpeningCard = &opening
+	}
+
+	for s := 0; s < 4; s++ {
+		for _, p := range g.Players {
+			if p.Seat != s {
+				continue
+			}
+			view.Seats = append(view.Seats, SeatView{
+				Seat:           p.Seat,
+				UserID:         p.UserID,
+				CardsRemaining: len(p.Hand),
+				HasPassed:      p.HasPassed,
+				Finished:       p.Finished,
+				Frozen:         p.Frozen,
+			})
+			if p.Seat == seat {
+				view.Seat = seat
+				view.UserID = p.UserID
+				view.Hand = append([]Card(nil), p.Hand...)
+			}
+		}
+	}
+	return view
+}
+
+// RuleSet returns the rules of the viewed game, Southern when none are set.
+func (v PlayerView) RuleSet() RuleSet {
+	if v.Rules == nil {
+		return SouthernRules{}
+	}
+	return v.Rules
+}
+
+// Self returns the viewer's own seat state; false for spectators.
+func (v PlayerView) Self() (SeatView, bool) {
+	return v.SeatView(v.Seat)
+}
+
+// SeatView returns the public state of the player in seat.
+func (v PlayerView) SeatView(seat int) (SeatView, bool) {
+	for _, s := range v.Seats {
+		if s.Seat == seat {
+			return s, true
+		}
+	}
+	return SeatView{}, false
+}
+
+// PassedSeats returns the seats that passed this round, in seat order.
+func (v PlayerView) PassedSeats() []int {
+	var seats []int
+	for _, s := range v.Seats {
+		if s.HasPassed {
+			seats = append(seats, s.Seat)
+		}
+	}
+	return seats
+}
+
+// LegalMoves returns the viewer's legal plays, restricted to plays that include the opening card
+// while one is required. It is empty for spectators and finished players.
+func (v PlayerView) LegalMoves() LegalMoveSet {
+	if self, ok := v.Self(); !ok || self.Finished {
+		return LegalMoveSet{ByType: map[CardCombinationType][]CardCombination{}}
+	}
+	moves := LegalMoves(v.Hand, v.LastPlayedCombination, v.RuleSet())
+	if v.OpeningCard != nil {
+		moves = moves.Containing(*v.OpeningCard)
+	}
+	return moves
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "testing"
+
+func viewTestGame(t *testing.T) *Game {
+	t.Helper()
+	return &Game{
+		Phase: PhasePlaying,
+		Players: map[string]*Player{
+			"alice": {UserID: "alice", Seat: 0, Hand: mustCards(t, "3S 4D 9H")},
+			"bob":   {UserID: "bob", Seat: 2, Hand: mustCards(t, "5C 2H"), HasPassed: true},
+			"carol": {UserID: "carol", Seat: 3, Finished: true},
+		},
+		CurrentTurn:           0,
+		LastPlayedCombination: IdentifyCombination(mustCards(t, "8S")),
+		LastPlayerToPlaySeat:  3,
+		FinishOrderSeats:      []int{3},
+		Discards:              mustCards(t, "7D 8S"),
+	}
+}
+
+func TestGameView_HidesOpponentHands(t *testing.T) {
+	game := viewTestGame(t)
+	view := game.View(0)
+
+	if view.UserID != "alice" || FormatCards(view.Hand, NotationText) != "3S 4D 9H" {
+		t.Fatalf("view for seat 0 = %s %v, want alice's hand", view.UserID, view.Hand)
+	}
+	want := []SeatView{
+		{Seat: 0, UserID: "alice", CardsRemaining: 3},
+		{Seat: 2, UserID: "bob", CardsRemaining: 2, HasPassed: true},
+		{Seat: 3, UserID: "carol", Finished: true},
+	}
+	if len(view.Seats) != len(want) {
+		t.Fatalf("got %d seats, want %d", len(view.Seats), len(want))
+	}
+	for i := range want {
+		if view.Seats[i] != want[i] {
+			t.Errorf("seat %d = %+v, want %+v", i, view.Seats[i], want[i])
+		}
+	}
+	if got := view.PassedSeats(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("PassedSeats() = %v, want [2]", got)
+	}
+
+	// The view is a snapshot: later changes to the game do not leak into it.
+	game.Players["alice"].Hand[0] = Card{Rank: 12, Suit: 3}
+	game.Discards[0] = Card{Rank: 12, Suit: 3}
+	if view.Hand[0] != (Card{Rank: 0, Suit: 0}) || view.Discards[0] != (Card{Rank: 4, Suit: 2}) {
+		t.Error("view shares slices with the game")
+	}
+}
+
+func TestGameView_Spectator(t *testing.T) {
+	view := viewTestGame(t).View(-1)
+	if view.Seat != -1 || view.UserID != "" || view.Hand != nil {
+		t.Errorf("spectator view = seat %d, user %q, hand %v; want no viewer", view.Seat, view.UserID, view.Hand)
+	}
+	if len(view.Seats) != 3 || len(view.Discards) != 2 {
+		t.Errorf("spectator should still see every seat and the discards, got %d seats, %d discards", len(view.Seats), len(view.Discards))
+	}
+	if view.LegalMoves().Len() != 0 {
+		t.Error("spectators have no legal moves")
+	}
+}
+
+func TestPlayerView_LegalMoves(t *testing.T) {
+	game := viewTestGame(t)
+	if got := game.View(0).LegalMoves().All(); len(got) != 1 || FormatCards(got[0].Cards, NotationText) != "9H" {
+		t.Errorf("alice's moves over 8S = %v, want only 9H", got)
+	}
+	if game.View(3).LegalMoves().Len() != 0 {
+		t.Error("finished players have no legal moves")
+	}
+}
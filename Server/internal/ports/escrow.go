@@ -0,0 +1,21 @@
+package ports
+
+import "context"
+
+// HeldStakes is the gold held back from player wallets for one running game.
+type HeldStakes struct {
+	Stakes  map[string]int64 `json:"stakes"`  // Gold taken from each human's wallet at the deal
+	Changes map[string]int64 `json:"changes"` // Net chop payments recorded so far
+}
+
+// EscrowPort persists the stakes held for running games, so they outlive a failed settlement or a crash.
+type EscrowPort interface {
+	// SaveStakes records the stakes held for a match, replacing any earlier record.
+	SaveStakes(ctx context.Context, matchID string, stakes HeldStakes) error
+
+	// DeleteStakes removes a match's record once its stakes were paid out or returned.
+	DeleteStakes(ctx context.Context, matchID string) error
+
+	// ListStakes returns every record still held, keyed by match ID.
+	ListStakes(ctx context.Context) (map[string]HeldStakes, error)
+}
@@ -36,21 +36,26 @@ func (a *NakamaEconomyAdapter) GetBalance(ctx context.Context, userID string) (i
 	return wallet["gold"], nil
 }
 
-// UpdateBalances applies multiple wallet changes.
+// UpdateBalances applies multiple wallet changes in one transaction; either all of them apply or none do.
 func (a *NakamaEconomyAdapter) UpdateBalances(ctx context.Context, updates []ports.WalletUpdate) error {
+	walletUpdates := make([]*runtime.WalletUpdate, 0, len(updates))
 	for _, update := range updates {
 		if update.Amount == 0 {
 			continue
 		}
 
-		changes := map[string]int64{
-			"gold": update.Amount,
-		}
+		walletUpdates = append(walletUpdates, &runtime.WalletUpdate{
+			UserID:    update.UserID,
+			Changeset: map[string]int64{"gold": update.Amount},
+			Metadata:  update.Metadata,
+		})
+	}
+	if len(walletUpdates) == 0 {
+		return nil
+	}
 
-		_, _, err := a.nk.WalletUpdate(ctx, update.UserID, changes, update.Metadata, true)
-		if err != nil {
-			return fmt.Errorf("failed to update wallet for user %s: %w", update.UserID, err)
-		}
+	if _, err := a.nk.WalletsUpdate(ctx, walletUpdates, true); err != nil {
+		return fmt.Errorf("failed to update wallets: %w", err)
 	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,244 @@
+package nakama
+
+import (
+	"context"
+	"sort"
+
+	"tienlen/internal/domain"
+	"tienlen/internal/ports"
+	pb "tienlen/proto"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// stakeEscrow is the gold held back from human wallets for the running game. Chop payments are recorded
+// against it as they happen and everything settles from it when the game ends, so a loser cannot spend
+// their stake elsewhere mid-game or end up with a negative wallet.
+type stakeEscrow struct {
+	Stakes  map[string]int64 `json:"stakes"`  // Gold taken from each human's wallet at the deal; bots have none
+	Changes map[string]int64 `json:"changes"` // Net chop payments so far, bots included
+}
+
+// settleAttempts is how many times a payout from the escrow is tried before it is given up on.
+// UpdateBalances is atomic, so a retry cannot pay anyone twice.
+const settleAttempts = 3
+
+// holdStakes moves each human's worst-case loss in game (see domain.Game.MaxLoss) from their wallet into a
+// new escrow, or their whole balance when it is smaller. It returns the players whose stake could not be
+// held, with ERROR_CODE_BALANCE_TOO_LOW for an empty wallet and ERROR_CODE_UNSPECIFIED for a wallet error;
+// the caller must not deal them in. Stakes already held stay in the escrow (see releaseStakes), which is
+// saved after every stake so it survives a crash (see recoverStakes).
+func (mh *matchHandler) holdStakes(ctx context.Context, state *MatchState, game *domain.Game, logger runtime.Logger) map[string]pb.ErrorCode {
+	state.Escrow = &stakeEscrow{Stakes: make(map[string]int64), Changes: make(map[string]int64)}
+	if state.Economy == nil {
+		return nil
+	}
+
+	unheld := make(map[string]pb.ErrorCode)
+	for userID, player := range game.Players {
+		if isBotUserId(userID) {
+			continue
+		}
+		balance, err := state.Economy.GetBalance(ctx, userID)
+		if err != nil {
+			logger.Error("holdStakes: Failed to get balance of %s: %v", userID, err)
+			unheld[userID] = pb.ErrorCode_ERROR_CODE_UNSPECIFIED
+			continue
+		}
+		stake := min(game.MaxLoss(player.Seat), balance)
+		if stake <= 0 {
+			unheld[userID] = pb.ErrorCode_ERROR_CODE_BALANCE_TOO_LOW
+			continue
+		}
+		err = state.Economy.UpdateBalances(ctx, []ports.WalletUpdate{{
+			UserID: userID,
+			Amount: -stake,
+			Metadata: map[string]interface{}{
+				"match_id": ctx.Value(runtime.RUNTIME_CTX_MATCH_ID),
+				"reason":   "stake_escrow",
+			},
+		}})
+		if err != nil {
+			logger.Error("holdStakes: Failed to hold stake of %s: %v", userID, err)
+			unheld[userID] = pb.ErrorCode_ERROR_CODE_UNSPECIFIED
+			continue
+		}
+		state.Escrow.Stakes[userID] = stake
+		mh.saveEscrow(ctx, state, logger)
+	}
+	return unheld
+}
+
+// saveEscrow persists the escrow of the running game, replacing the earlier record.
+func (mh *matchHandler) saveEscrow(ctx context.Context, state *MatchState, logger runtime.Logger) {
+	if state.EscrowStore == nil || state.Escrow == nil {
+		return
+	}
+	matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+	err := state.EscrowStore.SaveStakes(ctx, matchID, ports.HeldStakes{Stakes: state.Escrow.Stakes, Changes: state.Escrow.Changes})
+	if err != nil {
+		logger.Error("saveEscrow: Failed to save the stakes: %v", err)
+	}
+}
+
+// deleteEscrow removes the persisted escrow once its stakes were paid out or returned.
+func (mh *matchHandler) deleteEscrow(ctx context.Context, state *MatchState, logger runtime.Logger) {
+	if state.EscrowStore == nil {
+		return
+	}
+	matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+	if err := state.EscrowStore.DeleteStakes(ctx, matchID); err != nil {
+		logger.Error("deleteEscrow: Failed to delete the stakes: %v", err)
+	}
+}
+
+// updateBalances applies updates, trying up to settleAttempts times.
+func updateBalances(ctx context.Context, economy ports.EconomyPort, updates []ports.WalletUpdate) error {
+	var err error
+	for attempt := 0; attempt < settleAttempts; attempt++ {
+		if err = economy.UpdateBalances(ctx, updates); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// recordChop adds a chop's balance changes to the escrow; they are paid out when the game settles.
+func (e *stakeEscrow) recordChop(changes map[string]int64) {
+	for userID, amount := range changes {
+		e.Changes[userID] += amount
+	}
+}
+
+// settleStakes pays out the game from the escrow: the end-of-game changes plus the recorded chops, capped
+// to each loser's stake, with the rest of every stake returned. It clears the escrow and returns the
+// end-of-game changes after capping, which with the chop events add up to what each wallet received,
+// and a PenaltyStakeCap line item for every player whose balance change the cap moved. When the payout
+// keeps failing every stake is returned instead, and the changes undo the chops with no line items.
+func (mh *matchHandler) settleStakes(ctx context.Context, state *MatchState, logger runtime.Logger, changes map[string]int64) (map[string]int64, []domain.PenaltyItem) {
+	escrow := state.Escrow
+	state.Escrow = nil
+	if escrow == nil {
+		escrow = &stakeEscrow{Stakes: map[string]int64{}, Changes: map[string]int64{}}
+	}
+
+	totals := make(map[string]int64, len(changes))
+	for userID, amount := range changes {
+		totals[userID] = amount
+	}
+	for userID, amount := range escrow.Changes {
+		totals[userID] += amount
+	}
+	uncapped := make(map[string]int64, len(totals))
+	for userID, amount := range totals {
+		uncapped[userID] = amount
+	}
+	domain.CapToStakes(totals, escrow.Stakes)
+
+	settled := make(map[string]int64, len(totals))
+	var capItems []domain.PenaltyItem
+	for userID, amount := range totals {
+		settled[userID] = amount - escrow.Changes[userID]
+
+		delta := amount - uncapped[userID]
+		if delta == 0 || state.Game == nil || state.Game.Players[userID] == nil {
+			continue
+		}
+		player := state.Game.Players[userID]
+		item := domain.PenaltyItem{PayerSeat: -1, PayeeSeat: player.Seat, Reason: domain.PenaltyStakeCap, Amount: delta}
+		if delta < 0 {
+			item = domain.PenaltyItem{PayerSeat: player.Seat, PayeeSeat: -1, Reason: domain.PenaltyStakeCap, Amount: -delta}
+		}
+		capItems = append(capItems, item)
+	}
+	sort.Slice(capItems, func(i, j int) bool {
+		return max(capItems[i].PayerSeat, capItems[i].PayeeSeat) < max(capItems[j].PayerSeat, capItems[j].PayeeSeat)
+	})
+
+	if state.Economy != nil {
+		updates := make([]ports.WalletUpdate, 0, len(totals))
+		for userID, amount := range totals {
+			// Skip bots
+			if isBotUserId(userID) {
+				continue
+			}
+			updates = append(updates, ports.WalletUpdate{
+				UserID: userID,
+				Amount: escrow.Stakes[userID] + amount,
+				Metadata: map[string]interface{}{
+					"match_id": ctx.Value(runtime.RUNTIME_CTX_MATCH_ID),
+					"reason":   "game_settlement",
+					"stake":    escrow.Stakes[userID],
+				},
+			})
+		}
+		if err := updateBalances(ctx, state.Economy, updates); err != nil {
+			logger.Error("settleStakes: Failed to settle, returning the stakes instead: %v", err)
+			mh.returnStakes(ctx, state, logger, escrow)
+			for userID := range settled {
+				settled[userID] = -escrow.Changes[userID]
+			}
+			return settled, nil
+		}
+	}
+	mh.deleteEscrow(ctx, state, logger)
+	return settled, capItems
+}
+
+// releaseStakes returns every stake untouched, for a game that ends without a result.
+func (mh *matchHandler) releaseStakes(ctx context.Context, state *MatchState, logger runtime.Logger) {
+	escrow := state.Escrow
+	state.Escrow = nil
+	if escrow == nil || state.Economy == nil {
+		return
+	}
+	mh.returnStakes(ctx, state, logger, escrow)
+}
+
+// returnStakes pays every stake in escrow back to its wallet. The persisted escrow is kept when that
+// fails, so the stakes are returned on the next start (see recoverStakes).
+func (mh *matchHandler) returnStakes(ctx context.Context, state *MatchState, logger runtime.Logger, escrow *stakeEscrow) {
+	matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+	if err := updateBalances(ctx, state.Economy, stakeReturns(matchID, escrow.Stakes, "stake_release")); err != nil {
+		logger.Error("returnStakes: Failed to return stakes %v: %v", escrow.Stakes, err)
+		return
+	}
+	mh.deleteEscrow(ctx, state, logger)
+}
+
+// stakeReturns builds the wallet updates that pay stakes back, tagged with the match and reason.
+func stakeReturns(matchID string, stakes map[string]int64, reason string) []ports.WalletUpdate {
+	updates := make([]ports.WalletUpdate, 0, len(stakes))
+	for userID, stake := range stakes {
+		updates = append(updates, ports.WalletUpdate{
+			UserID: userID,
+			Amount: stake,
+			Metadata: map[string]interface{}{
+				"match_id": matchID,
+				"reason":   reason,
+			},
+		})
+	}
+	return updates
+}
+
+// recoverStakes returns the stakes of games that never settled, because the server stopped mid-game
+// or every payout failed. It runs at startup, before any match exists, so every record is orphaned.
+func recoverStakes(ctx context.Context, logger runtime.Logger, store ports.EscrowPort, economy ports.EconomyPort) {
+	held, err := store.ListStakes(ctx)
+	if err != nil {
+		logger.Error("recoverStakes: Failed to list held stakes: %v", err)
+		return
+	}
+	for matchID, stakes := range held {
+		if err := economy.UpdateBalances(ctx, stakeReturns(matchID, stakes.Stakes, "stake_recovery")); err != nil {
+			logger.Error("recoverStakes: Failed to return stakes of match %s: %v", matchID, err)
+			continue
+		}
+		if err := store.DeleteStakes(ctx, matchID); err != nil {
+			logger.Error("recoverStakes: Failed to delete stakes of match %s: %v", matchID, err)
+			continue
+		}
+		logger.Info("recoverStakes: Returned stakes %v of match %s.", stakes.Stakes, matchID)
+	}
+}
@@ -0,0 +1,74 @@
+package nakama
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"tienlen/internal/ports"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// stakeEscrowCollection holds one system-owned object per match with stakes held, keyed by match ID.
+const stakeEscrowCollection = "stake_escrows"
+
+// NakamaEscrowAdapter implements ports.EscrowPort using Nakama storage.
+type NakamaEscrowAdapter struct {
+	nk runtime.NakamaModule
+}
+
+// NewNakamaEscrowAdapter creates a new escrow adapter.
+func NewNakamaEscrowAdapter(nk runtime.NakamaModule) *NakamaEscrowAdapter {
+	return &NakamaEscrowAdapter{nk: nk}
+}
+
+// SaveStakes records the stakes held for a match, replacing any earlier record.
+func (a *NakamaEscrowAdapter) SaveStakes(ctx context.Context, matchID string, stakes ports.HeldStakes) error {
+	value, err := json.Marshal(stakes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stakes: %w", err)
+	}
+	_, err = a.nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      stakeEscrowCollection,
+		Key:             matchID,
+		Value:           string(value),
+		PermissionRead:  runtime.STORAGE_PERMISSION_NO_READ,
+		PermissionWrite: runtime.STORAGE_PERMISSION_NO_WRITE,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to write stakes of match %s: %w", matchID, err)
+	}
+	return nil
+}
+
+// DeleteStakes removes a match's record once its stakes were paid out or returned.
+func (a *NakamaEscrowAdapter) DeleteStakes(ctx context.Context, matchID string) error {
+	if err := a.nk.StorageDelete(ctx, []*runtime.StorageDelete{{Collection: stakeEscrowCollection, Key: matchID}}); err != nil {
+		return fmt.Errorf("failed to delete stakes of match %s: %w", matchID, err)
+	}
+	return nil
+}
+
+// ListStakes returns every record still held, keyed by match ID.
+func (a *NakamaEscrowAdapter) ListStakes(ctx context.Context) (map[string]ports.HeldStakes, error) {
+	held := make(map[string]ports.HeldStakes)
+	cursor := ""
+	for {
+		objects, next, err := a.nk.StorageList(ctx, "", "", stakeEscrowCollection, 100, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stakes: %w", err)
+		}
+		for _, object := range objects {
+			var stakes ports.HeldStakes
+			if err := json.Unmarshal([]byte(object.GetValue()), &stakes); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal stakes of match %s: %w", object.GetKey(), err)
+			}
+			held[object.GetKey()] = stakes
+		}
+		if next == "" {
+			return held, nil
+		}
+		cursor = next
+	}
+}
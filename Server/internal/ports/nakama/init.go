@@ -28,9 +28,18 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 	if err := initializer.RegisterRpc("find_match", RpcFindMatch); err != nil {
 		return err
 	}
+	if err := initializer.RegisterRpc("create_private_room", RpcCreatePrivateRoom); err != nil {
+		return err
+	}
+	if err := initializer.RegisterRpc("join_by_code", RpcJoinByCode); err != nil {
+		return err
+	}
 	if err := initializer.RegisterRpc("get_vivox_token", RpcGetVivoxToken); err != nil {
 		return err
 	}
+	if err := initializer.RegisterRpc("verify_deal", RpcVerifyDeal); err != nil {
+		return err
+	}
 
 	if err := initializer.RegisterRpc("set_vip", RpcSetVip); err != nil {
 		return err
@@ -51,6 +60,9 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 		return err
 	}
 
+	// Return the stakes of games the last run did not settle.
+	recoverStakes(ctx, logger, NewNakamaEscrowAdapter(nk), NewNakamaEconomyAdapter(nk))
+
 	// Initialize Bots
 	if err := bot.LoadIdentities("data/bot_identities.json"); err != nil {
 		logger.Warn("InitModule: Could not load bot identities: %v", err)
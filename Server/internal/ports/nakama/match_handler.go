@@ -2,10 +2,12 @@ package nakama
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"tienlen/internal/app"
@@ -22,12 +24,21 @@ import (
 )
 
 const (
-	MatchLabelKey_OpenSeats        = "open" // Key for the open seats in the match label
-	MatchLabelKey_Type             = "type" // Key for the match type in the match label
-	gameStartTurnTimerBonusSeconds = 5      // Extra seconds added to the first turn timer to cover card dealing.
-	lobbyAutoFillBotMax            = 2      // Max bots to auto-fill when a single human is waiting.
+	MatchLabelKey_OpenSeats        = "open"    // Key for the open seats in the match label
+	MatchLabelKey_Type             = "type"    // Key for the match type in the match label
+	MatchLabelKey_Private          = "private" // Key for the private room flag in the match label
+	MatchLabelKey_Tier             = "tier"    // Key for the bet tier ID in the match label
+	MatchLabelKey_Rules            = "rules"   // Key for the rule set name in the match label
+	gameStartTurnTimerBonusSeconds = 5         // Extra seconds added to the first turn timer to cover card dealing.
+	lobbyAutoFillBotMax            = 2         // Max bots to auto-fill when a single human is waiting.
+	maxClientSaltLength            = 64        // Client salts are truncated to this many characters each.
+	defaultStartCountdownSeconds   = 10        // Lobby auto-start countdown when the config does not set one.
+	defaultRematchVoteSeconds      = 20        // Rematch vote window when the config does not set one.
 )
 
+// roleSpectator is the join metadata "role" value for watching a table without a seat.
+const roleSpectator = "spectator"
+
 // MatchState holds the authoritative runtime state for the Nakama match handler.
 type MatchState struct {
 	Seats                [4]string                   `json:"seats"`                   // Array of user IDs, empty string means seat is empty
@@ -45,8 +56,34 @@ type MatchState struct {
 	LastSinglePlayerTick int64                       `json:"last_single_player_tick"` // Tick when a single player started waiting
 	TurnSecondsRemaining int64                       `json:"turn_seconds_remaining"`  // Seconds remaining before the current turn expires
 	Bots                 map[string]*bot.Agent       `json:"-"`                       // Active bot agents
+	Autopilots           map[string]*bot.Agent       `json:"-"`                       // Agents playing for disconnected humans, keyed by the human's user ID
+	ReconnectGrace       int                         `json:"reconnect_grace"`         // Seconds a disconnected player's seat is held
+	DisconnectedUntil    map[string]int64            `json:"disconnected_until"`      // Held seats: user ID -> tick the grace period ends
+	SpectatorsAllowed    bool                        `json:"spectators_allowed"`      // Owner setting; spectators are rejected when false
+	Spectators           map[string]runtime.Presence `json:"-"`                       // Watching users by user ID; they hold no seat and get public events only
+	SpectatorQueue       []string                    `json:"spectator_queue"`         // Spectator user IDs in join order, first in line for a free seat
+	JoiningSpectators    map[string]bool             `json:"-"`                       // Accepted with the spectator role, waiting for MatchJoin
 	Economy              ports.EconomyPort           `json:"-"`                       // Interface to Nakama wallet
+	Escrow               *stakeEscrow                `json:"escrow"`                  // Stakes held for the running game; nil between games
+	EscrowStore          ports.EscrowPort            `json:"-"`                       // Persists Escrow so held stakes outlive a failed settlement or a crash
 	Type                 pb.MatchType                `json:"type"`                    // Match type (Casual, VIP, etc.)
+	NextServerSeed       []byte                      `json:"-"`                       // Secret seed for the next deal; only its commitment is published
+	Hints                config.HintSettings         `json:"-"`                       // Hint limits for this match type
+	Advisors             map[string]*bot.Advisor     `json:"-"`                       // Hint advisors of the current game, created on first request
+	HintsUsed            map[string]int              `json:"hints_used"`              // Hints requested per user this game
+	HintReadyTick        map[string]int64            `json:"hint_ready_tick"`         // Tick from which each user may request the next hint
+	Private              bool                        `json:"private"`                 // Private rooms are joined by code and hidden from find_match
+	RoomCode             string                      `json:"room_code"`               // Code that resolves to this match; empty for public matches
+	Password             string                      `json:"-"`                       // Join password of a private room; empty for none
+	Tier                 string                      `json:"tier"`                    // Bet tier ID; MatchInit resolves an empty one to the default tier
+	Rules                string                      `json:"rules"`                   // Rule set name (see domain.RuleSetByName); MatchInit resolves an unknown one to Southern
+	TurnDuration         int                         `json:"turn_duration"`           // Seconds per turn; 0 uses the configured duration
+	Ready                map[string]bool             `json:"ready"`                   // Humans ready to start the next game, by user ID
+	ClientSalts          map[string]string           `json:"client_salts"`            // Salts sent with a ready or rematch vote, by user ID; mixed into the next deal
+	StartCountdown       int64                       `json:"start_countdown"`         // Seconds until the lobby starts the game; 0 when not counting down
+	RematchUntil         int64                       `json:"rematch_until"`           // Tick the rematch vote closes; 0 when no vote is open
+	RematchVotes         map[string]bool             `json:"rematch_votes"`           // Users seated when the rematch vote opened and whether they voted yes
+	Forfeits             map[string]bool             `json:"forfeits"`                // Players who left a running game; their seat is freed when it ends
 }
 
 func (ms *MatchState) GetOpenSeatsCount() int {
@@ -69,6 +106,78 @@ func (ms *MatchState) GetOccupiedSeatCount() int {
 	return count
 }
 
+// connectedSeats returns Seats with the seats held for disconnected players, and those of players
+// who forfeited the running game, left empty.
+func (ms *MatchState) connectedSeats() []string {
+	seats := ms.Seats
+	for i, userID := range seats {
+		if _, ok := ms.DisconnectedUntil[userID]; ok || ms.Forfeits[userID] {
+			seats[i] = ""
+		}
+	}
+	return seats[:]
+}
+
+// seatOf returns the seat index of a user or -1 if they are not seated.
+func (ms *MatchState) seatOf(userID string) int {
+	for i, seatUserID := range ms.Seats {
+		if seatUserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// readySeats is connectedSeats with the humans who are not ready blanked out. Bots are always ready.
+func (ms *MatchState) readySeats() []string {
+	seats := ms.connectedSeats()
+	for i, userID := range seats {
+		if userID != "" && !isBotUserId(userID) && !ms.Ready[userID] {
+			seats[i] = ""
+		}
+	}
+	return seats
+}
+
+// dealSalt joins the client salts of the players in seats, in seat order, so that everyone who readied up
+// or voted for the deal contributes to its shuffle (see domain.DealSeed).
+func (ms *MatchState) dealSalt(seats []string) string {
+	var salts []string
+	for _, userID := range seats {
+		if salt := ms.ClientSalts[userID]; salt != "" {
+			salts = append(salts, salt)
+		}
+	}
+	return strings.Join(salts, "|")
+}
+
+// setClientSalt keeps the salt a player sent for the next deal.
+func (ms *MatchState) setClientSalt(userID, salt string) {
+	if ms.ClientSalts == nil {
+		ms.ClientSalts = make(map[string]string)
+	}
+	ms.ClientSalts[userID] = truncateSalt(salt)
+}
+
+// truncateSalt cuts a salt sent by a client to maxClientSaltLength characters.
+func truncateSalt(salt string) string {
+	if runes := []rune(salt); len(runes) > maxClientSaltLength {
+		return string(runes[:maxClientSaltLength])
+	}
+	return salt
+}
+
+// readyPlayers returns how many connected players are ready to start and whether a human is among them.
+func (ms *MatchState) readyPlayers() (count int, humanReady bool) {
+	for _, userID := range ms.readySeats() {
+		if userID != "" {
+			count++
+			humanReady = humanReady || !isBotUserId(userID)
+		}
+	}
+	return count, humanReady
+}
+
 func (ms *MatchState) GetHumanPlayerCount() int {
 	count := 0
 	for _, seat := range ms.Seats {
@@ -84,6 +193,25 @@ func isBotUserId(userId string) bool {
 	return bot.IsBot(userId)
 }
 
+// tierBalanceError returns the ErrorCode that keeps userID from playing at tier, or ERROR_CODE_UNSPECIFIED
+// when their balance is within the tier's limits. Bots, and tiers without limits, always pass.
+func tierBalanceError(ctx context.Context, economy ports.EconomyPort, tier config.BetTier, userID string) (pb.ErrorCode, error) {
+	if economy == nil || isBotUserId(userID) || (tier.MinBalance == 0 && tier.MaxBalance == 0) {
+		return pb.ErrorCode_ERROR_CODE_UNSPECIFIED, nil
+	}
+	balance, err := economy.GetBalance(ctx, userID)
+	if err != nil {
+		return pb.ErrorCode_ERROR_CODE_UNSPECIFIED, err
+	}
+	switch {
+	case balance < tier.MinBalance:
+		return pb.ErrorCode_ERROR_CODE_BALANCE_TOO_LOW, nil
+	case !tier.Admits(balance):
+		return pb.ErrorCode_ERROR_CODE_BALANCE_TOO_HIGH, nil
+	}
+	return pb.ErrorCode_ERROR_CODE_UNSPECIFIED, nil
+}
+
 // isHumanSeat reports whether the seat index belongs to a human player.
 func isHumanSeat(seats []string, seatIndex int) bool {
 	if seatIndex < 0 || seatIndex >= len(seats) {
@@ -108,6 +236,56 @@ func shouldTerminateNoHumans(seats []string) bool {
 	return findFirstHumanSeat(seats) == -1
 }
 
+// matchTypeName converts a MatchType to the lower-case key used in game config (e.g. "ranked").
+func matchTypeName(t pb.MatchType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "MATCH_TYPE_"))
+}
+
+// intParam reads an integer match parameter, which arrives as float64 when decoded from JSON.
+func intParam(params map[string]interface{}, key string) (int, bool) {
+	switch v := params[key].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
+
+// toDomainChopPenalties converts a configured chop table to its domain form.
+func toDomainChopPenalties(t config.ChopPenaltyTable) domain.ChopPenaltyTable {
+	return domain.ChopPenaltyTable{
+		BlackPig:        t.BlackPig,
+		RedPig:          t.RedPig,
+		BlackPairOfTwos: t.BlackPairOfTwos,
+		MixedPairOfTwos: t.MixedPairOfTwos,
+		RedPairOfTwos:   t.RedPairOfTwos,
+		ThreePine:       t.ThreePine,
+		Quad:            t.Quad,
+		FourPine:        t.FourPine,
+		FivePine:        t.FivePine,
+	}
+}
+
+// toDomainSettlementPolicy converts a configured settlement policy to its domain form.
+// The config is validated at load time, so unknown types fall back to the standard rank matrix.
+func toDomainSettlementPolicy(p config.SettlementPolicyConfig) domain.SettlementPolicy {
+	var tax domain.Tax
+	if p.TaxRate != nil {
+		tax.Rate = *p.TaxRate
+	}
+
+	switch p.Type {
+	case config.SettlementWinnerTakesAll:
+		return domain.WinnerTakesAllPolicy{LoserMultiplier: p.LoserMultiplier, Tax: tax}
+	case config.SettlementCardCount:
+		return domain.CardCountPolicy{CardMultiplier: p.CardMultiplier, Tax: tax}
+	case config.SettlementRankMatrix:
+		return domain.RankMatrixPolicy{Matrix: p.RankMatrix, Tax: tax}
+	}
+	return domain.RankMatrixPolicy{Matrix: domain.DefaultRankMatrix(), Tax: tax}
+}
+
 // NewMatch is the factory function registered with Nakama.
 func NewMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error) {
 	return &matchHandler{}, nil
@@ -125,29 +303,71 @@ func (mh *matchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db
 	}
 
 	state := &MatchState{
-		Tick:           time.Now().Unix(),
-		Presences:      make(map[string]runtime.Presence),
-		App:            app.NewService(nil), // Initialize the app service
-		OwnerSeat:      -1,
-		LastWinnerSeat: -1,
-		Bots:           make(map[string]*bot.Agent),
-		Economy:        NewNakamaEconomyAdapter(nk),
-		Type:           pb.MatchType_MATCH_TYPE_CASUAL,
+		Tick:              time.Now().Unix(),
+		Presences:         make(map[string]runtime.Presence),
+		OwnerSeat:         -1,
+		LastWinnerSeat:    -1,
+		Bots:              make(map[string]*bot.Agent),
+		Autopilots:        make(map[string]*bot.Agent),
+		DisconnectedUntil: make(map[string]int64),
+		SpectatorsAllowed: true,
+		Spectators:        make(map[string]runtime.Presence),
+		JoiningSpectators: make(map[string]bool),
+		Ready:             make(map[string]bool),
+		Economy:           NewNakamaEconomyAdapter(nk),
+		EscrowStore:       NewNakamaEscrowAdapter(nk),
+		Type:              pb.MatchType_MATCH_TYPE_CASUAL,
+	}
+
+	if t, ok := intParam(params, "type"); ok {
+		state.Type = pb.MatchType(int32(t))
+	}
+
+	// Private rooms carry the settings their creator chose (see RpcCreatePrivateRoom).
+	state.Private, _ = params["private"].(bool)
+	state.RoomCode, _ = params["code"].(string)
+	state.Password, _ = params["password"].(string)
+	tier, _ := params["tier"].(string)
+	state.Tier = config.ResolveTierID(tier)
+	if d, ok := intParam(params, "turn_duration"); ok && d > 0 {
+		state.TurnDuration = d
+	}
+
+	state.Hints = config.GetHintSettings(matchTypeName(state.Type))
+
+	// Standard rules open a fresh table with the 3 of Spades; params may turn it off.
+	ruleOpts := domain.RuleOptions{OpeningCard: true}
+	if val, ok := params["opening_card"].(bool); ok {
+		ruleOpts.OpeningCard = val
+	}
+	ruleName, _ := params["rules"].(string)
+	rules, err := domain.RuleSetByName(ruleName, ruleOpts)
+	if err != nil {
+		logger.Warn("MatchInit: Unknown rule set %q, using %s rules.", ruleName, domain.RuleSetSouthern)
+		rules, _ = domain.RuleSetByName(domain.RuleSetSouthern, ruleOpts)
 	}
-
-	if val, ok := params["type"]; ok {
-		if t, ok := val.(float64); ok {
-			state.Type = pb.MatchType(int32(t))
-		} else if t, ok := val.(int); ok {
-			state.Type = pb.MatchType(int32(t))
-		}
+	state.Rules = rules.Name()
+	appOpts := []app.Option{app.WithRuleSet(rules)}
+	if table, ok := config.GetChopPenalties(state.Tier, matchTypeName(state.Type)); ok {
+		appOpts = append(appOpts, app.WithChopPenalties(toDomainChopPenalties(table)))
 	}
-
-	// Read environment variables for bot configuration
+	if policy, ok := config.GetSettlementPolicy(state.Tier); ok {
+		appOpts = append(appOpts, app.WithSettlementPolicy(toDomainSettlementPolicy(policy)))
+	}
+	// Read environment variables for debug and bot configuration
 	env := ctx.Value(runtime.RUNTIME_CTX_ENV).(map[string]string)
+	if val, ok := env["tienlen_debug_invariants"]; ok && val == "true" {
+		appOpts = append(appOpts, app.WithInvariantChecks(true))
+	}
+	state.App = app.NewService(nil, appOpts...)
+	mh.rotateServerSeed(state, logger)
+
 	if val, ok := env["tienlen_bots_enabled"]; ok {
 		state.BotsEnabled = val == "true"
 	}
+	if val, ok := params["bots_enabled"].(bool); ok {
+		state.BotsEnabled = val
+	}
 	if val, ok := env["tienlen_bot_min_delay_sec"]; ok {
 		if i, err := strconv.Atoi(val); err == nil {
 			state.BotMinDelay = i
@@ -179,11 +399,27 @@ func (mh *matchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db
 		state.BotAutoFillDelay = defaultAutoFillDelay
 	}
 
+	state.ReconnectGrace = -1
+	if val, ok := env["tienlen_reconnect_grace_sec"]; ok {
+		if i, err := strconv.Atoi(val); err == nil {
+			state.ReconnectGrace = i
+		}
+	}
+	if state.ReconnectGrace < 0 {
+		state.ReconnectGrace = 0
+		if cfg := config.GetGameConfig(); cfg != nil {
+			state.ReconnectGrace = cfg.ReconnectGraceSeconds
+		}
+	}
+
 	// Initial match label: 4 open seats, lobby state
 	label := &pb.MatchLabel{
-		Open:  int32(state.GetOpenSeatsCount()),
-		State: "lobby",
-		Type:  int32(state.Type),
+		Open:    int32(state.GetOpenSeatsCount()),
+		State:   "lobby",
+		Type:    int32(state.Type),
+		Private: state.Private,
+		Tier:    state.Tier,
+		Rules:   state.Rules,
 	}
 	labelBytes, err := (&protojson.MarshalOptions{EmitUnpopulated: true}).Marshal(label)
 	if err != nil {
@@ -201,6 +437,32 @@ func (mh *matchHandler) MatchJoinAttempt(ctx context.Context, logger runtime.Log
 		return state, false, "state not found"
 	}
 
+	// A disconnected player always gets their held seat back.
+	if _, ok := matchState.DisconnectedUntil[presence.GetUserId()]; ok {
+		return state, true, ""
+	}
+	// A player who walked out of the running game may come back once it is over.
+	if matchState.Forfeits[presence.GetUserId()] {
+		return state, false, "Left this game"
+	}
+
+	// A private room's password admits players and spectators alike.
+	if matchState.Password != "" && subtle.ConstantTimeCompare([]byte(metadata["password"]), []byte(matchState.Password)) != 1 {
+		return state, false, "Wrong password"
+	}
+
+	// Spectators need no seat, only the owner's permission.
+	if metadata["role"] == roleSpectator {
+		if !matchState.SpectatorsAllowed {
+			return state, false, "Spectating disabled"
+		}
+		if matchState.JoiningSpectators == nil {
+			matchState.JoiningSpectators = make(map[string]bool)
+		}
+		matchState.JoiningSpectators[presence.GetUserId()] = true
+		return state, true, ""
+	}
+
 	// Allow join if there is an empty seat OR a bot to replace (if game hasn't started)
 	if matchState.GetOpenSeatsCount() <= 0 {
 		hasBot := false
@@ -217,6 +479,17 @@ func (mh *matchHandler) MatchJoinAttempt(ctx context.Context, logger runtime.Log
 		}
 	}
 
+	// Players must be able to cover the tier's stakes; the reason is a structured error (see errorPayload).
+	tier, _ := config.GetBetTier(matchState.Tier)
+	code, err := tierBalanceError(ctx, matchState.Economy, tier, presence.GetUserId())
+	if err != nil {
+		logger.Warn("MatchJoinAttempt: Failed to check balance of %s: %v", presence.GetUserId(), err)
+		return state, false, errorPayload(pb.ErrorCode_ERROR_CODE_UNSPECIFIED, pb.ErrorCategory_ERROR_CATEGORY_TRANSIENT, true)
+	}
+	if code != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+		return state, false, errorPayload(code, pb.ErrorCategory_ERROR_CATEGORY_ACCESS, false)
+	}
+
 	return state, true, ""
 }
 
@@ -229,10 +502,28 @@ func (mh *matchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 
 	logger.Info("MatchJoin: %d users joining match.", len(presences))
 
+	var rejoined, spectators []runtime.Presence
 	for _, p := range presences {
+		if matchState.JoiningSpectators[p.GetUserId()] {
+			delete(matchState.JoiningSpectators, p.GetUserId())
+			mh.addSpectator(matchState, p)
+			logger.Info("MatchJoin: User %s is spectating.", p.GetUserId())
+			spectators = append(spectators, p)
+			continue
+		}
+
 		// Store presence
 		matchState.Presences[p.GetUserId()] = p
 
+		// A returning player takes back their held seat, and control from the autopilot.
+		if _, ok := matchState.DisconnectedUntil[p.GetUserId()]; ok {
+			delete(matchState.DisconnectedUntil, p.GetUserId())
+			delete(matchState.Autopilots, p.GetUserId())
+			logger.Info("MatchJoin: User %s rejoined seat %d.", p.GetUserId(), matchState.seatOf(p.GetUserId()))
+			rejoined = append(rejoined, p)
+			continue
+		}
+
 		// Assign seat: Try empty seats first, then bots (if lobby)
 		assigned := false
 		for i, seatUserId := range matchState.Seats {
@@ -261,9 +552,9 @@ func (mh *matchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 		}
 	}
 
-	// Ensure owner seat is assigned to a human player only.
-	if !isHumanSeat(matchState.Seats[:], matchState.OwnerSeat) {
-		matchState.OwnerSeat = findFirstHumanSeat(matchState.Seats[:])
+	// Ensure owner seat is assigned to a connected human player only.
+	if seats := matchState.connectedSeats(); !isHumanSeat(seats, matchState.OwnerSeat) {
+		matchState.OwnerSeat = findFirstHumanSeat(seats)
 		if matchState.OwnerSeat >= 0 {
 			logger.Debug("MatchJoin: Owner set to human seat %d.", matchState.OwnerSeat)
 		}
@@ -275,6 +566,15 @@ func (mh *matchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 	// Broadcast the current match state to all presences after join.
 	mh.broadcastMatchState(ctx, matchState, dispatcher, logger)
 
+	// Players returning to a game in progress also get their hand and the table back;
+	// spectators get the table.
+	for _, p := range rejoined {
+		mh.sendGameResync(matchState, dispatcher, logger, p)
+	}
+	if len(spectators) > 0 {
+		mh.sendSpectatorResync(matchState, dispatcher, logger, spectators)
+	}
+
 	return matchState
 }
 
@@ -287,13 +587,32 @@ func (mh *matchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, d
 	}
 
 	ownerLeft := false
+	held := false
 	for _, p := range presences {
+		if mh.removeSpectator(matchState, p.GetUserId()) {
+			logger.Debug("MatchLeave: Spectator %s left.", p.GetUserId())
+			continue
+		}
 		delete(matchState.Presences, p.GetUserId())
+		delete(matchState.Ready, p.GetUserId())
+
+		// Leaving on purpose forfeits a running game; a dropped connection holds the seat.
+		if p.GetReason() == runtime.PresenceReasonLeave && mh.forfeitSeat(ctx, matchState, dispatcher, logger, p.GetUserId()) {
+			mh.broadcastPlayerLeft(matchState, dispatcher, logger, matchState.seatOf(p.GetUserId()), p.GetUserId())
+			continue
+		}
+
+		if mh.holdSeat(matchState, p.GetUserId(), logger) {
+			held = true
+			ownerLeft = ownerLeft || (matchState.OwnerSeat >= 0 && matchState.Seats[matchState.OwnerSeat] == p.GetUserId())
+			continue
+		}
 
 		for i, seatUserId := range matchState.Seats {
 			if seatUserId == p.GetUserId() {
 				matchState.Seats[i] = ""
 				logger.Debug("MatchLeave: User %s left, seat %d freed.", p.GetUserId(), i)
+				mh.broadcastPlayerLeft(matchState, dispatcher, logger, i, p.GetUserId())
 
 				if matchState.OwnerSeat == i {
 					ownerLeft = true
@@ -303,7 +622,7 @@ func (mh *matchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, d
 		}
 	}
 
-	newOwnerSeat := findFirstHumanSeat(matchState.Seats[:])
+	newOwnerSeat := findFirstHumanSeat(matchState.connectedSeats())
 	if newOwnerSeat != matchState.OwnerSeat {
 		matchState.OwnerSeat = newOwnerSeat
 		if newOwnerSeat >= 0 {
@@ -313,16 +632,238 @@ func (mh *matchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, d
 		}
 	}
 
+	// Held seats keep the match alive until their players return or the grace period ends.
 	if shouldTerminateNoHumans(matchState.Seats[:]) {
 		logger.Info("MatchLeave: Terminating match with no humans.")
+		releaseRoomCode(ctx, nk, logger, matchState.RoomCode)
 		return nil
 	}
 
 	mh.updateLabel(matchState, dispatcher, logger)
+	if held {
+		// Let the table see which seats are now autopiloted.
+		mh.broadcastMatchState(ctx, matchState, dispatcher, logger)
+	}
 
 	return matchState
 }
 
+// broadcastPlayerLeft tells the table that a seated player left the match.
+func (mh *matchHandler) broadcastPlayerLeft(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, seat int, userID string) {
+	bytes, err := proto.Marshal(&pb.PlayerLeftEvent{Seat: int32(seat), UserId: userID})
+	if err != nil {
+		logger.Error("broadcastPlayerLeft: Failed to marshal PlayerLeftEvent: %v", err)
+		return
+	}
+	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_PLAYER_LEFT), bytes, nil, nil, true)
+}
+
+// forfeitSeat takes a player who chose to leave out of the running game (see app.Service.LeaveGame).
+// Their seat stays taken until the game ends, so nobody sits in it mid-game, and they settle with
+// everyone else. It reports whether the player forfeited; players who already finished do not.
+func (mh *matchHandler) forfeitSeat(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, userID string) bool {
+	game := state.Game
+	if game == nil || game.Phase != domain.PhasePlaying {
+		return false
+	}
+	player, ok := game.Players[userID]
+	if !ok || player.Finished {
+		return false
+	}
+
+	events, err := state.App.LeaveGame(game, player.Seat)
+	if err != nil {
+		logger.Error("MatchLeave: Failed to forfeit seat %d: %v", player.Seat, err)
+		return false
+	}
+	if state.Forfeits == nil {
+		state.Forfeits = make(map[string]bool)
+	}
+	state.Forfeits[userID] = true
+	logger.Info("MatchLeave: User %s left the game, seat %d forfeits.", userID, player.Seat)
+
+	if len(events) > 0 {
+		mh.resetTurnSecondsRemaining(state, logger)
+	}
+	for _, ev := range events {
+		mh.broadcastEvent(ctx, state, dispatcher, logger, ev)
+	}
+	return true
+}
+
+// addSpectator registers a watching user at the back of the queue for a free seat.
+func (mh *matchHandler) addSpectator(state *MatchState, presence runtime.Presence) {
+	if state.Spectators == nil {
+		state.Spectators = make(map[string]runtime.Presence)
+	}
+	if _, ok := state.Spectators[presence.GetUserId()]; !ok {
+		state.SpectatorQueue = append(state.SpectatorQueue, presence.GetUserId())
+	}
+	state.Spectators[presence.GetUserId()] = presence
+}
+
+// removeSpectator drops a spectator and reports whether the user was one.
+func (mh *matchHandler) removeSpectator(state *MatchState, userID string) bool {
+	if _, ok := state.Spectators[userID]; !ok {
+		return false
+	}
+	delete(state.Spectators, userID)
+	for i, queued := range state.SpectatorQueue {
+		if queued == userID {
+			state.SpectatorQueue = append(state.SpectatorQueue[:i], state.SpectatorQueue[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// seatSpectators moves spectators into free seats between games, first come first served.
+// Spectators whose balance is outside the tier's limits keep watching and stay in line.
+// It reports whether anyone was seated.
+func (mh *matchHandler) seatSpectators(ctx context.Context, state *MatchState, logger runtime.Logger) bool {
+	if state.Game != nil {
+		return false
+	}
+	tier, _ := config.GetBetTier(state.Tier)
+	seated := false
+	for _, userID := range append([]string(nil), state.SpectatorQueue...) {
+		seat := state.seatOf("") // First free seat
+		if seat < 0 {
+			break
+		}
+		code, err := tierBalanceError(ctx, state.Economy, tier, userID)
+		if err != nil {
+			logger.Warn("seatSpectators: Failed to check balance of %s: %v", userID, err)
+			continue
+		}
+		if code != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			logger.Debug("seatSpectators: Spectator %s keeps watching, balance outside tier %s limits.", userID, tier.ID)
+			continue
+		}
+		presence := state.Spectators[userID]
+		mh.removeSpectator(state, userID)
+
+		state.Seats[seat] = userID
+		state.Presences[userID] = presence
+		logger.Info("seatSpectators: Spectator %s took seat %d.", userID, seat)
+		seated = true
+	}
+	if seated && !isHumanSeat(state.connectedSeats(), state.OwnerSeat) {
+		state.OwnerSeat = findFirstHumanSeat(state.connectedSeats())
+	}
+	return seated
+}
+
+// sendSpectatorResync sends spectators the public view of the game in progress.
+func (mh *matchHandler) sendSpectatorResync(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, spectators []runtime.Presence) {
+	if state.Game == nil || state.Game.Phase != domain.PhasePlaying || len(spectators) == 0 {
+		return
+	}
+	bytes, err := proto.Marshal(toProtoResync(state.Game.View(-1), state.TurnSecondsRemaining))
+	if err != nil {
+		logger.Error("sendSpectatorResync: Failed to marshal GameResyncEvent: %v", err)
+		return
+	}
+	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_GAME_RESYNC), bytes, spectators, nil, true)
+}
+
+// spectatorPresences returns the presences of every spectator.
+func (ms *MatchState) spectatorPresences() []runtime.Presence {
+	presences := make([]runtime.Presence, 0, len(ms.Spectators))
+	for _, userID := range ms.SpectatorQueue {
+		if p, ok := ms.Spectators[userID]; ok {
+			presences = append(presences, p)
+		}
+	}
+	return presences
+}
+
+// holdSeat keeps a leaving human's seat for the reconnect grace period, and for the rest of the
+// running game when they are playing in it. It reports whether the seat is held.
+func (mh *matchHandler) holdSeat(state *MatchState, userID string, logger runtime.Logger) bool {
+	if isBotUserId(userID) || state.seatOf(userID) < 0 {
+		return false
+	}
+	inGame := mh.engageAutopilot(state, userID, logger)
+	if !inGame && state.ReconnectGrace <= 0 {
+		return false
+	}
+	if state.DisconnectedUntil == nil {
+		state.DisconnectedUntil = make(map[string]int64)
+	}
+	state.DisconnectedUntil[userID] = state.Tick + int64(state.ReconnectGrace)
+	logger.Debug("MatchLeave: Holding seat %d for %s until tick %d.", state.seatOf(userID), userID, state.DisconnectedUntil[userID])
+	return true
+}
+
+// engageAutopilot hands a leaving player's seat to a bot when they still hold a place in the running game.
+// It reports whether an autopilot took over.
+func (mh *matchHandler) engageAutopilot(state *MatchState, userID string, logger runtime.Logger) bool {
+	if state.Game == nil || state.Game.Phase != domain.PhasePlaying || isBotUserId(userID) {
+		return false
+	}
+	player, ok := state.Game.Players[userID]
+	if !ok {
+		return false
+	}
+	if state.Autopilots == nil {
+		state.Autopilots = make(map[string]*bot.Agent)
+	}
+	state.Autopilots[userID] = bot.NewAutopilot(state.Game.View(player.Seat))
+	logger.Info("MatchLeave: User %s disconnected; autopilot plays seat %d.", userID, player.Seat)
+	return true
+}
+
+// releaseExpiredSeats frees held seats whose grace period has ended, except seats an autopilot
+// is still playing, and once the game is over the seats of players who forfeited it.
+// Players who did not return are announced as gone. It reports whether any seat was freed.
+func (mh *matchHandler) releaseExpiredSeats(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger) bool {
+	released := false
+	if state.Game == nil {
+		for userID := range state.Forfeits {
+			if seat := state.seatOf(userID); seat >= 0 {
+				state.Seats[seat] = ""
+				logger.Debug("releaseExpiredSeats: Seat %d of forfeited player %s freed.", seat, userID)
+			}
+			delete(state.Forfeits, userID)
+			released = true
+		}
+	}
+	for userID, until := range state.DisconnectedUntil {
+		if _, playing := state.Autopilots[userID]; playing || state.Tick < until {
+			continue
+		}
+		if seat := state.seatOf(userID); seat >= 0 {
+			state.Seats[seat] = ""
+			logger.Debug("releaseExpiredSeats: User %s did not return, seat %d freed.", userID, seat)
+			mh.broadcastPlayerLeft(state, dispatcher, logger, seat, userID)
+		}
+		delete(state.DisconnectedUntil, userID)
+		released = true
+	}
+	return released
+}
+
+// sendGameResync sends a rejoining player their view of the game in progress.
+func (mh *matchHandler) sendGameResync(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, presence runtime.Presence) {
+	game := state.Game
+	if game == nil || game.Phase != domain.PhasePlaying {
+		return
+	}
+	player, ok := game.Players[presence.GetUserId()]
+	if !ok {
+		return
+	}
+
+	event := toProtoResync(game.View(player.Seat), state.TurnSecondsRemaining)
+	bytes, err := proto.Marshal(event)
+	if err != nil {
+		logger.Error("sendGameResync: Failed to marshal GameResyncEvent: %v", err)
+		return
+	}
+	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_GAME_RESYNC), bytes, []runtime.Presence{presence}, nil, true)
+}
+
 func (mh *matchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
 	matchState, ok := state.(*MatchState)
 	if !ok {
@@ -330,6 +871,7 @@ func (mh *matchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 	}
 
 	matchState.Tick = tick
+	wasPlaying := matchState.Game != nil
 
 	// Handle incoming messages
 	for _, msg := range messages {
@@ -343,6 +885,14 @@ func (mh *matchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 			mh.handlePassTurn(ctx, matchState, dispatcher, logger, msg)
 		case int64(pb.OpCode_OP_CODE_IN_GAME_CHAT):
 			mh.handleInGameChat(ctx, matchState, dispatcher, logger, msg)
+		case int64(pb.OpCode_OP_CODE_REQUEST_HINT):
+			mh.handleRequestHint(ctx, matchState, dispatcher, logger, msg)
+		case int64(pb.OpCode_OP_CODE_SET_SPECTATING):
+			mh.handleSetSpectating(ctx, matchState, dispatcher, logger, msg)
+		case int64(pb.OpCode_OP_CODE_SET_READY):
+			mh.handleSetReady(ctx, matchState, dispatcher, logger, msg)
+		case int64(pb.OpCode_OP_CODE_REQUEST_NEW_GAME):
+			mh.handleRequestNewGame(ctx, matchState, dispatcher, logger, msg)
 		default:
 			logger.Warn("MatchLoop: Unknown opcode received: %d", msg.GetOpCode())
 		}
@@ -371,14 +921,43 @@ func (mh *matchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 		}
 	}
 
-	// AI Logic
-	if matchState.BotsEnabled {
+	// Non-voters lose their seats when the rematch vote closes.
+	dropped := mh.tickRematchVote(ctx, matchState, dispatcher, logger)
+	mh.tickStartCountdown(ctx, matchState, dispatcher, logger)
+
+	// AI Logic; autopilots play even where bots may not fill seats.
+	if matchState.BotsEnabled || len(matchState.Autopilots) > 0 {
 		mh.processBots(ctx, matchState, dispatcher, logger, nk)
 	}
 
+	// Free the seats of players who did not reconnect in time, then fill free seats with spectators.
+	released := mh.releaseExpiredSeats(matchState, dispatcher, logger)
+	seated := mh.seatSpectators(ctx, matchState, logger)
+	ended := wasPlaying && matchState.Game == nil // Seats may have been freed when the game ended
+	if ended || dropped || released || seated {
+		if shouldTerminateNoHumans(matchState.Seats[:]) {
+			logger.Info("MatchLoop: Terminating match with no humans.")
+			releaseRoomCode(ctx, nk, logger, matchState.RoomCode)
+			return nil
+		}
+		mh.updateLabel(matchState, dispatcher, logger)
+		mh.broadcastMatchState(ctx, matchState, dispatcher, logger)
+	}
+
 	return matchState
 }
 
+// rotateServerSeed draws the secret seed for the next deal. On failure the seed is cleared and the game cannot start.
+func (mh *matchHandler) rotateServerSeed(state *MatchState, logger runtime.Logger) {
+	seed, err := state.App.NewServerSeed()
+	if err != nil {
+		logger.Error("rotateServerSeed: %v", err)
+		state.NextServerSeed = nil
+		return
+	}
+	state.NextServerSeed = seed
+}
+
 func (mh *matchHandler) resetTurnSecondsRemaining(state *MatchState, logger runtime.Logger) {
 	mh.resetTurnSecondsRemainingWithBonus(state, logger, 0)
 }
@@ -391,7 +970,9 @@ func (mh *matchHandler) resetTurnSecondsRemainingWithBonus(state *MatchState, lo
 	}
 
 	duration := 16 // Default
-	if cfg := config.GetGameConfig(); cfg != nil {
+	if state.TurnDuration > 0 {
+		duration = state.TurnDuration
+	} else if cfg := config.GetGameConfig(); cfg != nil {
 		duration = cfg.TurnDurationSeconds
 	}
 
@@ -494,8 +1075,9 @@ func (mh *matchHandler) processBots(ctx context.Context, state *MatchState, disp
 	if state.Game != nil && state.Game.Phase == domain.PhasePlaying {
 		currentTurn := state.Game.CurrentTurn
 		currentUserID := state.Seats[currentTurn]
+		autopilot, autopiloted := state.Autopilots[currentUserID]
 
-		if isBotUserId(currentUserID) {
+		if isBotUserId(currentUserID) || autopiloted {
 			if state.BotWaitUntil == 0 {
 				// Initialize random delay
 				delay := rand.Intn(state.BotMaxDelay-state.BotMinDelay+1) + state.BotMinDelay
@@ -507,6 +1089,9 @@ func (mh *matchHandler) processBots(ctx context.Context, state *MatchState, disp
 				state.BotWaitUntil = 0 // Reset for next turn
 
 				agent, exists := state.Bots[currentUserID]
+				if autopiloted {
+					agent, exists = autopilot, true
+				}
 				if !exists {
 					// Fallback if agent missing (shouldn't happen for new bots)
 					var err error
@@ -518,7 +1103,7 @@ func (mh *matchHandler) processBots(ctx context.Context, state *MatchState, disp
 					state.Bots[currentUserID] = agent
 				}
 
-				move, err := agent.Play(state.Game)
+				move, err := agent.Play(state.Game.View(currentTurn))
 				if err != nil {
 					logger.Error("processBots: Bot %s failed to calculate move: %v", currentUserID, err)
 					return
@@ -561,13 +1146,15 @@ func (mh *matchHandler) broadcastMatchState(ctx context.Context, state *MatchSta
 		if p, exists := state.Presences[userId]; exists {
 			displayName = p.GetUsername()
 		}
+		_, autopilot := state.Autopilots[userId]
+		ready := state.Ready[userId] || isBotUserId(userId)
 
 		if botCfg, isBot := bot.GetBotConfig(userId); isBot {
 			displayName = botCfg.DisplayName
 			avatarIndex = botCfg.AvatarIndex
-		} else {
+		} else if adapter, ok := state.Economy.(*NakamaEconomyAdapter); ok {
 			// Fetch VIP status from storage for humans
-			objects, err := state.Economy.(*NakamaEconomyAdapter).nk.StorageRead(ctx, []*runtime.StorageRead{
+			objects, err := adapter.nk.StorageRead(ctx, []*runtime.StorageRead{
 				{
 					Collection: "profiles",
 					Key:        "vip_status",
@@ -613,16 +1200,28 @@ func (mh *matchHandler) broadcastMatchState(ctx context.Context, state *MatchSta
 			AvatarIndex:    int32(avatarIndex),
 			Balance:        balance,
 			IsVip:          isVip,
+			Autopilot:      autopilot,
+			Ready:          ready,
 		})
 	}
 
 	snapshot := &pb.MatchStateSnapshot{
-		Seats:                state.Seats[:],
-		OwnerSeat:            int32(state.OwnerSeat),
-		Tick:                 state.Tick,
-		TurnSecondsRemaining: state.TurnSecondsRemaining,
-		Players:              playerStates,
-		Type:                 int32(state.Type),
+		Seats:                 state.Seats[:],
+		OwnerSeat:             int32(state.OwnerSeat),
+		Tick:                  state.Tick,
+		TurnSecondsRemaining:  state.TurnSecondsRemaining,
+		Players:               playerStates,
+		Type:                  int32(state.Type),
+		SpectatorCount:        int32(len(state.Spectators)),
+		SpectatorsAllowed:     state.SpectatorsAllowed,
+		RoomCode:              state.RoomCode,
+		StartCountdownSeconds: state.StartCountdown,
+		Tier:                  state.Tier,
+		BaseBet:               config.GetBaseBet(state.Tier),
+		Rules:                 state.Rules,
+	}
+	if state.NextServerSeed != nil {
+		snapshot.DealCommitment = domain.DealSeed{ServerSeed: state.NextServerSeed}.Commitment()
 	}
 	bytes, _ := proto.Marshal(snapshot)
 	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_PLAYER_JOINED), bytes, nil, nil, true)
@@ -652,30 +1251,100 @@ func (mh *matchHandler) handleStartGame(ctx context.Context, state *MatchState,
 		return
 	}
 
-	minPlayers := 2
-	if cfg := config.GetGameConfig(); cfg != nil {
-		minPlayers = cfg.MinPlayersToStartGame
+	// The owner may start without waiting for the ready check; every connected player is dealt in.
+	mh.startGame(ctx, state, dispatcher, logger, truncateSalt(request.GetClientSalt()), state.connectedSeats())
+}
+
+// startGame deals a new game to the given seats, a copy of state.Seats with the players who sit this game
+// out blanked (see connectedSeats and readySeats). The salt is mixed into the deal seed.
+// It reports whether a game was started.
+func (mh *matchHandler) startGame(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, salt string, seats []string) bool {
+	minPlayers := minPlayersToStart()
+
+	// Players whose balance left the tier's limits since they sat down sit this game out too.
+	tier, _ := config.GetBetTier(state.Tier)
+	for i, userID := range seats {
+		if userID == "" {
+			continue
+		}
+		code, err := tierBalanceError(ctx, state.Economy, tier, userID)
+		if err != nil {
+			logger.Warn("StartGame: Failed to check balance of %s: %v", userID, err)
+			mh.sendError(state, dispatcher, logger, userID, 503, "balance unavailable")
+			seats[i] = ""
+		} else if code != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			logger.Info("StartGame: User %s sits out, balance outside tier %s limits.", userID, tier.ID)
+			mh.sendAppError(state, dispatcher, logger, userID, 403, code, "balance outside the table limits")
+			seats[i] = ""
+		}
 	}
+	baseBet := config.GetBaseBet(state.Tier)
 
-	activeCount := state.GetOccupiedSeatCount()
-	if activeCount < minPlayers {
-		logger.Warn("StartGame: Cannot start with %d players. Need at least %d.", activeCount, minPlayers)
-		return
+	// Deal from the seed committed in earlier snapshots, salted by the starting player.
+	if state.NextServerSeed == nil {
+		mh.rotateServerSeed(state, logger)
+		if state.NextServerSeed == nil {
+			return false
+		}
 	}
+	seed := domain.DealSeed{ServerSeed: state.NextServerSeed, ClientSalt: salt}
+
+	var game *domain.Game
+	var events []app.Event
+	for {
+		activeCount := 0
+		for _, userID := range seats {
+			if userID != "" {
+				activeCount++
+			}
+		}
+		if activeCount < minPlayers {
+			logger.Warn("StartGame: Cannot start with %d players. Need at least %d.", activeCount, minPlayers)
+			return false
+		}
 
-	// Resolve BaseBet (Default to "casual" or configured default if params missing)
-	// TODO: Get tier from match metadata/params
-	baseBet := config.GetBaseBet("") // Uses default tier
+		// Initialize the domain Game via the Service
+		var err error
+		game, events, err = state.App.StartGameWithSeed(seats, state.LastWinnerSeat, baseBet, seed)
+		if err != nil {
+			logger.Error("StartGame: Failed to start game: %v", err)
+			return false
+		}
 
-	// Initialize the domain Game via the Service
-	game, events, err := state.App.StartGame(state.Seats[:], state.LastWinnerSeat, baseBet)
-	if err != nil {
-		logger.Error("StartGame: Failed to start game: %v", err)
-		return
+		// Players whose stake could not be held sit out like a failed balance check, and the same
+		// seed is dealt again to the rest. Nothing of the first deal has been sent yet.
+		unheld := mh.holdStakes(ctx, state, game, logger)
+		if len(unheld) == 0 {
+			break
+		}
+		mh.releaseStakes(ctx, state, logger)
+		for i, userID := range seats {
+			code, ok := unheld[userID]
+			if !ok {
+				continue
+			}
+			if code == pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+				mh.sendError(state, dispatcher, logger, userID, 503, "balance unavailable")
+			} else {
+				logger.Info("StartGame: User %s sits out, no balance to stake.", userID)
+				mh.sendAppError(state, dispatcher, logger, userID, 403, code, "balance outside the table limits")
+			}
+			seats[i] = ""
+		}
 	}
+	// A seed is never dealt twice; the next game gets a fresh commitment.
+	mh.rotateServerSeed(state, logger)
 
 	// Store the authoritative game state
 	state.Game = game
+	state.Advisors = make(map[string]*bot.Advisor)
+	state.HintsUsed = make(map[string]int)
+	// Players ready up again for the next game; an owner start also ends any rematch vote.
+	state.Ready = make(map[string]bool)
+	state.ClientSalts = nil
+	state.StartCountdown = 0
+	state.RematchUntil = 0
+	state.RematchVotes = nil
 
 	// Update match label to reflect playing state
 	mh.updateLabel(state, dispatcher, logger)
@@ -687,8 +1356,225 @@ func (mh *matchHandler) handleStartGame(ctx context.Context, state *MatchState,
 	for _, ev := range events {
 		mh.broadcastEvent(ctx, state, dispatcher, logger, ev)
 	}
+	// GameStartedEvent carries hands and only goes to players; spectators get the public table.
+	mh.sendSpectatorResync(state, dispatcher, logger, state.spectatorPresences())
+
+	logger.Info("StartGame: Game started with %d players.", len(game.Players))
+	return true
+}
+
+// minPlayersToStart returns the configured minimum number of players for a game.
+func minPlayersToStart() int {
+	minPlayers := 2
+	if cfg := config.GetGameConfig(); cfg != nil {
+		minPlayers = cfg.MinPlayersToStartGame
+	}
+	return minPlayers
+}
+
+// handleSetReady toggles the sender's ready flag for the next game. Only seated players in the lobby may
+// ready up; the countdown itself runs in tickStartCountdown.
+func (mh *matchHandler) handleSetReady(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, msg runtime.MatchData) {
+	senderID := msg.GetUserId()
+	if state.seatOf(senderID) < 0 {
+		mh.sendError(state, dispatcher, logger, senderID, 400, app.ErrUnknownPlayer.Error())
+		return
+	}
+	if state.Game != nil {
+		mh.sendError(state, dispatcher, logger, senderID, 400, "game already in progress")
+		return
+	}
+
+	request := &pb.SetReadyRequest{}
+	if err := proto.Unmarshal(msg.GetData(), request); err != nil {
+		logger.Warn("handleSetReady: Invalid SetReadyRequest from %s: %v", senderID, err)
+		return
+	}
+
+	if state.Ready == nil {
+		state.Ready = make(map[string]bool)
+	}
+	if request.GetReady() {
+		state.Ready[senderID] = true
+		state.setClientSalt(senderID, request.GetClientSalt())
+	} else {
+		delete(state.Ready, senderID)
+		delete(state.ClientSalts, senderID)
+	}
+	logger.Info("handleSetReady: User %s set ready=%t.", senderID, request.GetReady())
+
+	mh.broadcastMatchState(ctx, state, dispatcher, logger)
+}
+
+// tickStartCountdown runs the lobby auto-start, once per tick. The countdown starts when the minimum number
+// of players, including at least one human, are ready; it stops if readiness drops below that and starts the
+// game when it reaches zero. Changes are published in MatchStateSnapshot.
+func (mh *matchHandler) tickStartCountdown(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger) {
+	if state.Game != nil || state.RematchUntil != 0 {
+		return
+	}
+
+	ready, humanReady := state.readyPlayers()
+	if ready < minPlayersToStart() || !humanReady {
+		if state.StartCountdown > 0 {
+			logger.Info("StartCountdown: Cancelled, %d players ready.", ready)
+			state.StartCountdown = 0
+			mh.broadcastMatchState(ctx, state, dispatcher, logger)
+		}
+		return
+	}
+
+	if state.StartCountdown == 0 {
+		seconds := defaultStartCountdownSeconds
+		if cfg := config.GetGameConfig(); cfg != nil && cfg.StartCountdownSeconds > 0 {
+			seconds = cfg.StartCountdownSeconds
+		}
+		state.StartCountdown = int64(seconds)
+		logger.Info("StartCountdown: %d players ready, starting in %d seconds.", ready, seconds)
+		mh.broadcastMatchState(ctx, state, dispatcher, logger)
+		return
+	}
+
+	state.StartCountdown--
+	if state.StartCountdown > 0 {
+		return
+	}
+	// Only the players who readied up are dealt in, salted by all of them; the rest keep their seats and
+	// sit this game out.
+	seats := state.readySeats()
+	if !mh.startGame(ctx, state, dispatcher, logger, state.dealSalt(seats), seats) {
+		// Try again with a fresh countdown rather than every tick.
+		state.StartCountdown = 0
+	}
+}
+
+// openRematchVote opens the vote for a rematch after a game ends among the players seated now. Bots vote
+// yes straight away; players seated later, who never saw the vote, count as yes votes too.
+func (mh *matchHandler) openRematchVote(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger) {
+	seconds := defaultRematchVoteSeconds
+	if cfg := config.GetGameConfig(); cfg != nil && cfg.RematchVoteSeconds > 0 {
+		seconds = cfg.RematchVoteSeconds
+	}
+	state.RematchUntil = state.Tick + int64(seconds)
+	state.RematchVotes = make(map[string]bool)
+	state.ClientSalts = nil
+	for _, userID := range state.Seats {
+		if userID != "" {
+			state.RematchVotes[userID] = isBotUserId(userID)
+		}
+	}
+	logger.Info("Rematch: Vote open for %d seconds.", seconds)
+	mh.broadcastRematchVote(state, dispatcher, logger)
+}
+
+// rematchWaiting returns the seated users who have not voted for a rematch, in seat order.
+func (ms *MatchState) rematchWaiting() []string {
+	var waiting []string
+	for _, userID := range ms.Seats {
+		if ms.awaitsRematchVote(userID) {
+			waiting = append(waiting, userID)
+		}
+	}
+	return waiting
+}
+
+// awaitsRematchVote reports whether userID was seated when the rematch vote opened and has not voted yet.
+func (ms *MatchState) awaitsRematchVote(userID string) bool {
+	voted, inVote := ms.RematchVotes[userID]
+	return inVote && !voted
+}
+
+// broadcastRematchVote publishes who has voted for a rematch and how long the vote stays open.
+func (mh *matchHandler) broadcastRematchVote(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger) {
+	event := &pb.RematchVoteEvent{SecondsRemaining: state.RematchUntil - state.Tick}
+	if event.SecondsRemaining < 0 {
+		event.SecondsRemaining = 0
+	}
+	for seat, userID := range state.Seats {
+		switch {
+		case userID == "":
+		case state.awaitsRematchVote(userID):
+			event.WaitingSeats = append(event.WaitingSeats, int32(seat))
+		default:
+			event.VotedSeats = append(event.VotedSeats, int32(seat))
+		}
+	}
+	bytes, err := proto.Marshal(event)
+	if err != nil {
+		logger.Error("broadcastRematchVote: Failed to marshal RematchVoteEvent: %v", err)
+		return
+	}
+	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_REMATCH_VOTE), bytes, nil, nil, true)
+}
+
+// handleRequestNewGame records the sender's vote for a rematch while the vote is open.
+func (mh *matchHandler) handleRequestNewGame(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, msg runtime.MatchData) {
+	senderID := msg.GetUserId()
+	if state.RematchUntil == 0 {
+		mh.sendError(state, dispatcher, logger, senderID, 400, "no rematch vote is open")
+		return
+	}
+	if state.seatOf(senderID) < 0 {
+		mh.sendError(state, dispatcher, logger, senderID, 400, app.ErrUnknownPlayer.Error())
+		return
+	}
+
+	request := &pb.RequestNewGameRequest{}
+	if err := proto.Unmarshal(msg.GetData(), request); err != nil {
+		logger.Warn("handleRequestNewGame: Invalid RequestNewGameRequest from %s: %v", senderID, err)
+		return
+	}
+
+	if !state.awaitsRematchVote(senderID) {
+		return // Already voted, or seated after the vote opened and counted as a yes vote
+	}
+	state.RematchVotes[senderID] = true
+	state.setClientSalt(senderID, request.GetClientSalt())
+	logger.Info("handleRequestNewGame: User %s voted for a rematch.", senderID)
+	mh.broadcastRematchVote(state, dispatcher, logger)
+}
+
+// tickRematchVote closes the rematch vote once every seated player has voted or time runs out.
+// Players who did not vote in time lose their seat and are removed from the match; the rest play
+// the next game straight away, led by the last winner. It reports whether any seat was released.
+func (mh *matchHandler) tickRematchVote(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger) bool {
+	if state.RematchUntil == 0 {
+		return false
+	}
+	waiting := state.rematchWaiting()
+	if len(waiting) > 0 && state.Tick < state.RematchUntil {
+		return false
+	}
 
-	logger.Info("StartGame: Game started with %d players.", activeCount)
+	var kicked []runtime.Presence
+	for _, userID := range waiting {
+		seat := state.seatOf(userID)
+		state.Seats[seat] = ""
+		mh.broadcastPlayerLeft(state, dispatcher, logger, seat, userID)
+		delete(state.DisconnectedUntil, userID)
+		delete(state.Ready, userID)
+		if p, ok := state.Presences[userID]; ok {
+			kicked = append(kicked, p)
+			delete(state.Presences, userID)
+		}
+		logger.Info("Rematch: User %s did not vote, seat released.", userID)
+	}
+	if len(kicked) > 0 {
+		if err := dispatcher.MatchKick(kicked); err != nil {
+			logger.Warn("Rematch: Failed to remove non-voters: %v", err)
+		}
+	}
+	if len(waiting) > 0 {
+		state.OwnerSeat = findFirstHumanSeat(state.connectedSeats())
+	}
+
+	state.RematchUntil = 0
+	state.RematchVotes = nil
+	seats := state.connectedSeats()
+	if !mh.startGame(ctx, state, dispatcher, logger, state.dealSalt(seats), seats) {
+		logger.Info("Rematch: Too few players agreed, back to the lobby.")
+	}
+	return len(waiting) > 0
 }
 
 func (mh *matchHandler) handlePlayCards(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, msg runtime.MatchData) {
@@ -733,7 +1619,7 @@ func (mh *matchHandler) handlePlayCards(ctx context.Context, state *MatchState,
 				}
 			}
 		}
-		logger.Warn("handlePlayCards: User %s (seat %d) failed to play cards: %v. Requested: %+v, Hand: %+v", senderID, senderSeat, err, domainCards, hand)
+		logger.Warn("handlePlayCards: User %s (seat %d) failed to play cards: %v. Requested: [%s], Hand: [%s]", senderID, senderSeat, err, domain.FormatCards(domainCards, domain.NotationText), domain.FormatCards(hand, domain.NotationText))
 		mh.sendError(state, dispatcher, logger, senderID, 400, err.Error())
 		return
 	}
@@ -813,6 +1699,117 @@ func (mh *matchHandler) handleInGameChat(ctx context.Context, state *MatchState,
 	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_IN_GAME_CHAT), bytes, nil, nil, true)
 }
 
+// handleSetSpectating lets the owner allow or disallow spectators. Disallowing removes current spectators.
+func (mh *matchHandler) handleSetSpectating(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, msg runtime.MatchData) {
+	senderID := msg.GetUserId()
+	if state.OwnerSeat < 0 || state.Seats[state.OwnerSeat] != senderID {
+		mh.sendError(state, dispatcher, logger, senderID, 403, app.ErrNotOwner.Error())
+		return
+	}
+
+	request := &pb.SetSpectatingRequest{}
+	if err := proto.Unmarshal(msg.GetData(), request); err != nil {
+		logger.Warn("handleSetSpectating: Invalid SetSpectatingRequest from %s: %v", senderID, err)
+		return
+	}
+
+	state.SpectatorsAllowed = request.GetAllowed()
+	if !state.SpectatorsAllowed {
+		if spectators := state.spectatorPresences(); len(spectators) > 0 {
+			if err := dispatcher.MatchKick(spectators); err != nil {
+				logger.Warn("handleSetSpectating: Failed to remove spectators: %v", err)
+			}
+			for _, p := range spectators {
+				mh.removeSpectator(state, p.GetUserId())
+			}
+		}
+	}
+	logger.Info("handleSetSpectating: Owner %s set spectators allowed=%t.", senderID, state.SpectatorsAllowed)
+
+	mh.updateLabel(state, dispatcher, logger)
+	mh.broadcastMatchState(ctx, state, dispatcher, logger)
+}
+
+// handleRequestHint replies privately with the bot's top suggestions for the requester's turn.
+// Hints are limited per match type by config.HintSettings: disabled types answer 403 and
+// requests over the cooldown or the per-game allowance answer 429.
+func (mh *matchHandler) handleRequestHint(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, msg runtime.MatchData) {
+	senderID := msg.GetUserId()
+
+	if !state.Hints.Enabled {
+		mh.sendError(state, dispatcher, logger, senderID, 403, "hints are disabled in this match")
+		return
+	}
+	if state.Game == nil || state.Game.Phase != domain.PhasePlaying {
+		mh.sendError(state, dispatcher, logger, senderID, 400, app.ErrNotPlaying.Error())
+		return
+	}
+	player, ok := state.Game.Players[senderID]
+	if !ok || player.Finished {
+		mh.sendError(state, dispatcher, logger, senderID, 400, app.ErrUnknownPlayer.Error())
+		return
+	}
+	if player.Seat != state.Game.CurrentTurn {
+		mh.sendError(state, dispatcher, logger, senderID, 400, app.ErrNotYourTurn.Error())
+		return
+	}
+
+	if state.HintsUsed == nil {
+		state.HintsUsed = make(map[string]int)
+	}
+	if state.HintReadyTick == nil {
+		state.HintReadyTick = make(map[string]int64)
+	}
+	if state.Hints.MaxPerGame > 0 && state.HintsUsed[senderID] >= state.Hints.MaxPerGame {
+		mh.sendError(state, dispatcher, logger, senderID, 429, "no hints left this game")
+		return
+	}
+	if state.Tick < state.HintReadyTick[senderID] {
+		mh.sendError(state, dispatcher, logger, senderID, 429, "hint cooldown: wait "+strconv.FormatInt(state.HintReadyTick[senderID]-state.Tick, 10)+"s")
+		return
+	}
+
+	if state.Advisors == nil {
+		state.Advisors = make(map[string]*bot.Advisor)
+	}
+	view := state.Game.View(player.Seat)
+	advisor, ok := state.Advisors[senderID]
+	if !ok {
+		advisor = bot.NewAdvisor(view)
+		state.Advisors[senderID] = advisor
+	}
+
+	state.HintsUsed[senderID]++
+	state.HintReadyTick[senderID] = state.Tick + int64(state.Hints.CooldownSeconds)
+
+	event := &pb.HintEvent{
+		HintsRemaining:  -1,
+		CooldownSeconds: int64(state.Hints.CooldownSeconds),
+	}
+	if state.Hints.MaxPerGame > 0 {
+		event.HintsRemaining = int32(state.Hints.MaxPerGame - state.HintsUsed[senderID])
+	}
+	for _, s := range advisor.Suggest(view, state.Hints.Suggestions) {
+		event.Suggestions = append(event.Suggestions, &pb.HintSuggestion{
+			Pass:    s.Pass,
+			Cards:   toProtoCards(s.Cards),
+			Reasons: s.Reasons,
+		})
+	}
+	logger.Debug("handleRequestHint: %d suggestions for %s (seat %d)", len(event.Suggestions), senderID, player.Seat)
+
+	bytes, err := proto.Marshal(event)
+	if err != nil {
+		logger.Error("handleRequestHint: Failed to marshal HintEvent: %v", err)
+		return
+	}
+	presence, ok := state.Presences[senderID]
+	if !ok {
+		return
+	}
+	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_HINT), bytes, []runtime.Presence{presence}, nil, true)
+}
+
 // broadcastEvent handles the conversion and dispatching of app events to Nakama.
 func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, ev app.Event) {
 	var opCode int64
@@ -825,17 +1822,19 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 		logger.Debug("Event: game_started (firstTurnSeat=%d, handCount=%d, recipients=%d)", p.FirstTurnSeat, len(p.Hand), len(ev.Recipients))
 		// Log the hand being sent to the specific user
 		if len(ev.Recipients) > 0 {
-			logger.Info("StartGame: Dealing to %s: %+v", ev.Recipients[0], p.Hand)
+			logger.Info("StartGame: Dealing to %s: [%s]", ev.Recipients[0], domain.FormatCards(p.Hand, domain.NotationText))
 		}
 		payload = &pb.GameStartedEvent{
 			Phase:                pb.GamePhase_PHASE_PLAYING,
 			FirstTurnSeat:        int32(p.FirstTurnSeat),
 			Hand:                 toProtoCards(p.Hand),
 			TurnSecondsRemaining: state.TurnSecondsRemaining,
+			SeedCommitment:       p.SeedCommitment,
 		}
 	case app.EventCardPlayed:
 		opCode = int64(pb.OpCode_OP_CODE_CARD_PLAYED)
 		p := ev.Payload.(app.CardPlayedPayload)
+		logger.Debug("Event: card_played (seat=%d, cards=[%s], nextTurnSeat=%d, newRound=%t)", p.Seat, domain.FormatCards(p.Cards, domain.NotationText), p.NextTurnSeat, p.NewRound)
 		payload = &pb.CardPlayedEvent{
 			Seat:                 int32(p.Seat),
 			Cards:                toProtoCards(p.Cards),
@@ -846,6 +1845,16 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 	case app.EventPigChopped:
 		opCode = int64(pb.OpCode_OP_CODE_PIG_CHOPPED)
 		p := ev.Payload.(app.PigChoppedPayload)
+		logger.Info("Event: pig_chopped (source=%d, target=%d, type=%s, chopped=[%s], chopping=[%s])", p.SourceSeat, p.TargetSeat, p.ChopType, domain.FormatCards(p.CardsChopped, domain.NotationText), domain.FormatCards(p.CardsChopping, domain.NotationText))
+		protoChain := make([]*pb.ChopLink, 0, len(p.Chain))
+		for _, link := range p.Chain {
+			protoChain = append(protoChain, &pb.ChopLink{
+				Seat:     int32(link.Seat),
+				Cards:    toProtoCards(link.Cards),
+				ChopType: link.ChopType,
+				Amount:   link.Amount,
+			})
+		}
 		payload = &pb.PigChoppedEvent{
 			SourceSeat:     int32(p.SourceSeat),
 			TargetSeat:     int32(p.TargetSeat),
@@ -853,28 +1862,13 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 			CardsChopped:   toProtoCards(p.CardsChopped),
 			CardsChopping:  toProtoCards(p.CardsChopping),
 			BalanceChanges: p.BalanceChanges,
+			Chain:          protoChain,
 		}
 
-		// Apply Immediate Balance Changes
-		if state.Economy != nil {
-			updates := make([]ports.WalletUpdate, 0, len(p.BalanceChanges))
-			for userID, amount := range p.BalanceChanges {
-				// Skip bots
-				if isBotUserId(userID) {
-					continue
-				}
-				updates = append(updates, ports.WalletUpdate{
-					UserID: userID,
-					Amount: amount,
-					Metadata: map[string]interface{}{
-						"match_id": ctx.Value(runtime.RUNTIME_CTX_MATCH_ID),
-						"reason":   "pig_chop",
-					},
-				})
-			}
-			if err := state.Economy.UpdateBalances(ctx, updates); err != nil {
-				logger.Error("Failed to update balances for Pig Chop: %v", err)
-			}
+		// Chop payments settle from the stakes with the rest of the game (see settleStakes).
+		if state.Escrow != nil {
+			state.Escrow.recordChop(p.BalanceChanges)
+			mh.saveEscrow(ctx, state, logger)
 		}
 
 	case app.EventTurnPassed:
@@ -890,8 +1884,18 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 		opCode = int64(pb.OpCode_OP_CODE_PLAYER_FINISHED)
 		p := ev.Payload.(app.PlayerFinishedPayload)
 		payload = &pb.PlayerFinishedEvent{
-			Seat: int32(p.Seat),
-			Rank: int32(p.Rank),
+			Seat:   int32(p.Seat),
+			Rank:   int32(p.Rank),
+			Frozen: p.Frozen,
+		}
+	case app.EventInstantWin:
+		opCode = int64(pb.OpCode_OP_CODE_INSTANT_WIN)
+		p := ev.Payload.(app.InstantWinPayload)
+		logger.Info("Event: instant_win (seat=%d, type=%s, cards=[%s])", p.Seat, p.WinType, domain.FormatCards(p.Cards, domain.NotationText))
+		payload = &pb.InstantWinEvent{
+			Seat:    int32(p.Seat),
+			WinType: p.WinType,
+			Cards:   toProtoCards(p.Cards),
 		}
 	case app.EventGameEnded:
 		opCode = int64(pb.OpCode_OP_CODE_GAME_ENDED)
@@ -910,32 +1914,43 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 			}
 		}
 
+		protoFrozenSeats := make([]int32, len(p.FrozenSeats))
+		for i, seat := range p.FrozenSeats {
+			protoFrozenSeats[i] = int32(seat)
+		}
+
+		protoPenalties := make([]*pb.SettlementPenalty, 0, len(p.Penalties))
+		for _, item := range p.Penalties {
+			protoPenalties = append(protoPenalties, &pb.SettlementPenalty{
+				PayerSeat: int32(item.PayerSeat),
+				PayeeSeat: int32(item.PayeeSeat),
+				Reason:    item.Reason,
+				Cards:     toProtoCards(item.Cards),
+				Amount:    item.Amount,
+			})
+		}
+
+		// Settle from the stakes held since the deal; payouts shrink when a loser could not cover them,
+		// and a stake cap line item for each shrunk change keeps the penalties adding up to it.
+		balanceChanges, capItems := mh.settleStakes(ctx, state, logger, p.BalanceChanges)
+		for _, item := range capItems {
+			protoPenalties = append(protoPenalties, &pb.SettlementPenalty{
+				PayerSeat: int32(item.PayerSeat),
+				PayeeSeat: int32(item.PayeeSeat),
+				Reason:    item.Reason,
+				Amount:    item.Amount,
+			})
+		}
+
 		payload = &pb.GameEndedEvent{
 			FinishOrderSeats: protoSeats,
-			BalanceChanges:   p.BalanceChanges,
+			BalanceChanges:   balanceChanges,
 			RemainingHands:   protoRemainingHands,
-		}
-
-		// Apply Balance Changes to Nakama Wallets
-		if state.Economy != nil {
-			updates := make([]ports.WalletUpdate, 0, len(p.BalanceChanges))
-			for userID, amount := range p.BalanceChanges {
-				// Skip bots
-				if isBotUserId(userID) {
-					continue
-				}
-				updates = append(updates, ports.WalletUpdate{
-					UserID: userID,
-					Amount: amount,
-					Metadata: map[string]interface{}{
-						"match_id": ctx.Value(runtime.RUNTIME_CTX_MATCH_ID),
-						"reason":   "game_settlement",
-					},
-				})
-			}
-			if err := state.Economy.UpdateBalances(ctx, updates); err != nil {
-				logger.Error("Failed to update balances: %v", err)
-			}
+			Penalties:        protoPenalties,
+			FrozenSeats:      protoFrozenSeats,
+			SeedCommitment:   p.SeedCommitment,
+			ServerSeed:       p.ServerSeed,
+			ClientSalt:       p.ClientSalt,
 		}
 
 		// Save the winner for the next game
@@ -944,6 +1959,9 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 		}
 		// Game ended, clear game state and update label back to lobby
 		state.Game = nil
+		// Players still away keep their seat until their grace period ends (see releaseExpiredSeats).
+		state.Autopilots = make(map[string]*bot.Agent)
+		mh.releaseExpiredSeats(state, dispatcher, logger)
 		mh.updateLabel(state, dispatcher, logger)
 	default:
 		logger.Warn("Unknown event kind: %v", ev.Kind)
@@ -960,6 +1978,12 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 	for _, agent := range state.Bots {
 		agent.OnGameEvent(ev.Payload, ev.Recipients)
 	}
+	for _, agent := range state.Autopilots {
+		agent.OnGameEvent(ev.Payload, ev.Recipients)
+	}
+	for _, advisor := range state.Advisors {
+		advisor.OnGameEvent(ev.Payload, ev.Recipients)
+	}
 
 	// Determine recipients (default to broadcast)
 	var recipients []runtime.Presence
@@ -978,13 +2002,23 @@ func (mh *matchHandler) broadcastEvent(ctx context.Context, state *MatchState, d
 	}
 
 	dispatcher.BroadcastMessage(opCode, bytes, recipients, nil, true)
+
+	if ev.Kind == app.EventGameEnded {
+		mh.openRematchVote(state, dispatcher, logger)
+	}
 }
 
 // sendError sends a GameErrorEvent to a specific user.
 func (mh *matchHandler) sendError(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, userID string, code int, message string) {
+	mh.sendAppError(state, dispatcher, logger, userID, code, pb.ErrorCode_ERROR_CODE_UNSPECIFIED, message)
+}
+
+// sendAppError is sendError with an ErrorCode clients can handle specifically.
+func (mh *matchHandler) sendAppError(state *MatchState, dispatcher runtime.MatchDispatcher, logger runtime.Logger, userID string, code int, appCode pb.ErrorCode, message string) {
 	payload := &pb.GameErrorEvent{
 		Code:    int32(code),
 		Message: message,
+		AppCode: appCode,
 	}
 	bytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -1001,6 +2035,26 @@ func (mh *matchHandler) sendError(state *MatchState, dispatcher runtime.MatchDis
 	dispatcher.BroadcastMessage(int64(pb.OpCode_OP_CODE_GAME_ERROR), bytes, []runtime.Presence{presence}, nil, true)
 }
 
+// toProtoResync converts a player's view of the game to the resync payload. Hands other than the
+// viewer's never appear in a view, so the payload is safe to send to that viewer.
+func toProtoResync(view domain.PlayerView, turnSecondsRemaining int64) *pb.GameResyncEvent {
+	event := &pb.GameResyncEvent{
+		Hand:                 toProtoCards(view.Hand),
+		LastPlayedCards:      toProtoCards(view.LastPlayedCombination.Cards),
+		LastPlayerToPlaySeat: int32(view.LastPlayerToPlaySeat),
+		CurrentTurnSeat:      int32(view.CurrentTurn),
+		TurnSecondsRemaining: turnSecondsRemaining,
+		Discards:             toProtoCards(view.Discards),
+	}
+	for _, seat := range view.PassedSeats() {
+		event.PassedSeats = append(event.PassedSeats, int32(seat))
+	}
+	for _, seat := range view.FinishOrderSeats {
+		event.FinishOrderSeats = append(event.FinishOrderSeats, int32(seat))
+	}
+	return event
+}
+
 func toProtoCards(domainCards []domain.Card) []*pb.Card {
 	protoCards := make([]*pb.Card, len(domainCards))
 	for i, card := range domainCards {
@@ -1024,9 +2078,13 @@ func (mh *matchHandler) updateLabel(state *MatchState, dispatcher runtime.MatchD
 	}
 
 	label := &pb.MatchLabel{
-		Open:  int32(state.GetOpenSeatsCount()),
-		State: matchState,
-		Type:  int32(state.Type),
+		Open:       int32(state.GetOpenSeatsCount()),
+		State:      matchState,
+		Type:       int32(state.Type),
+		Spectators: int32(len(state.Spectators)),
+		Private:    state.Private,
+		Tier:       state.Tier,
+		Rules:      state.Rules,
 	}
 	labelBytes, err := (&protojson.MarshalOptions{EmitUnpopulated: true}).Marshal(label)
 	if err != nil {
@@ -1040,6 +2098,12 @@ func (mh *matchHandler) updateLabel(state *MatchState, dispatcher runtime.MatchD
 
 func (mh *matchHandler) MatchTerminate(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, reason int) interface{} {
 	logger.Debug("MatchTerminate: Match terminated for reason %d", reason)
+	// A game cut short by a shutdown has no result; everyone gets their stake back. A private room's
+	// code is freed for reuse.
+	if matchState, ok := state.(*MatchState); ok {
+		mh.releaseStakes(ctx, matchState, logger)
+		releaseRoomCode(ctx, nk, logger, matchState.RoomCode)
+	}
 	return state
 }
 
@@ -1058,19 +2122,24 @@ func (mh *matchHandler) MatchSignal(ctx context.Context, logger runtime.Logger,
 		return state, "invalid signal"
 	}
 
+	// A private room whose code could not be stored is closed before anyone can join it.
+	if signal.Op == "close" {
+		if len(matchState.Presences) > 0 || len(matchState.Spectators) > 0 {
+			return state, "match in use"
+		}
+		logger.Info("MatchSignal: Closing match.")
+		return nil, "closed"
+	}
+
 	if signal.Op == "start_with_deck" {
 		logger.Info("MatchSignal: Starting game with rigged deck.")
 
 		// Check standard constraints
-		minPlayers := 2
-		if cfg := config.GetGameConfig(); cfg != nil {
-			minPlayers = cfg.MinPlayersToStartGame
-		}
-		if matchState.GetOccupiedSeatCount() < minPlayers {
+		if matchState.GetOccupiedSeatCount() < minPlayersToStart() {
 			return state, "not enough players"
 		}
 
-		baseBet := config.GetBaseBet("")
+		baseBet := config.GetBaseBet(matchState.Tier)
 
 		// Call Service
 		game, events, err := matchState.App.StartGameWithDeck(matchState.Seats[:], matchState.LastWinnerSeat, baseBet, signal.Deck)
@@ -1079,6 +2148,11 @@ func (mh *matchHandler) MatchSignal(ctx context.Context, logger runtime.Logger,
 			return state, "failed to start"
 		}
 
+		if unheld := mh.holdStakes(ctx, matchState, game, logger); len(unheld) > 0 {
+			logger.Error("MatchSignal: Failed to hold stakes of %d players.", len(unheld))
+			mh.releaseStakes(ctx, matchState, logger)
+			return state, "failed to start"
+		}
 		matchState.Game = game
 		mh.updateLabel(matchState, dispatcher, logger)
 		mh.resetTurnSecondsRemainingWithBonus(matchState, logger, gameStartTurnTimerBonusSeconds)
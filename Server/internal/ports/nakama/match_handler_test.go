@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"math/rand"
+	"strings"
 	"testing"
+	"tienlen/internal/app"
 	"tienlen/internal/bot"
 	"tienlen/internal/config"
 	"tienlen/internal/domain"
@@ -74,12 +76,21 @@ type mockDispatcher struct {
 	labelUpdates   int
 	lastOpCode     int64
 	lastData       []byte
+	lastPresences  []runtime.Presence // Recipients of the last message; nil means everyone
+	lastLabel      string
+	kicked         []runtime.Presence
+	sent           map[int64][]byte // Last payload sent per opcode
 }
 
 func (md *mockDispatcher) BroadcastMessage(opCode int64, data []byte, presences []runtime.Presence, sender runtime.Presence, reliable bool) error {
 	md.broadcastCount++
 	md.lastOpCode = opCode
 	md.lastData = append([]byte(nil), data...)
+	md.lastPresences = presences
+	if md.sent == nil {
+		md.sent = make(map[int64][]byte)
+	}
+	md.sent[opCode] = md.lastData
 	return nil
 }
 
@@ -88,11 +99,13 @@ func (md *mockDispatcher) BroadcastMessageDeferred(opCode int64, data []byte, pr
 }
 
 func (md *mockDispatcher) MatchKick(presences []runtime.Presence) error {
+	md.kicked = append(md.kicked, presences...)
 	return nil
 }
 
 func (md *mockDispatcher) MatchLabelUpdate(label string) error {
 	md.labelUpdates++
+	md.lastLabel = label
 	return nil
 }
 
@@ -113,14 +126,52 @@ func (me *mockEconomy) GetBalance(ctx context.Context, userID string) (int64, er
 }
 
 func (me *mockEconomy) UpdateBalances(ctx context.Context, updates []ports.WalletUpdate) error {
+	for _, update := range updates {
+		if _, ok := me.balances[update.UserID]; ok {
+			me.balances[update.UserID] += update.Amount
+		}
+	}
+	return nil
+}
+
+// mockEscrowStore keeps persisted stakes in memory, keyed by match ID.
+type mockEscrowStore struct {
+	records map[string]ports.HeldStakes
+}
+
+func (ms *mockEscrowStore) SaveStakes(ctx context.Context, matchID string, stakes ports.HeldStakes) error {
+	if ms.records == nil {
+		ms.records = make(map[string]ports.HeldStakes)
+	}
+	copied := ports.HeldStakes{Stakes: make(map[string]int64), Changes: make(map[string]int64)}
+	for userID, amount := range stakes.Stakes {
+		copied.Stakes[userID] = amount
+	}
+	for userID, amount := range stakes.Changes {
+		copied.Changes[userID] = amount
+	}
+	ms.records[matchID] = copied
+	return nil
+}
+
+func (ms *mockEscrowStore) DeleteStakes(ctx context.Context, matchID string) error {
+	delete(ms.records, matchID)
 	return nil
 }
 
+func (ms *mockEscrowStore) ListStakes(ctx context.Context) (map[string]ports.HeldStakes, error) {
+	return ms.records, nil
+}
+
 func init() {
 	// Load bot identities for testing.
 	if err := bot.LoadIdentities("test_bot_identities.json"); err != nil {
 		panic("Failed to load bot identities for tests: " + err.Error())
 	}
+	// Tests run against the shipped game configuration.
+	if err := config.LoadGameConfig("../../../data/game_config.json"); err != nil {
+		panic("Failed to load game config for tests: " + err.Error())
+	}
 }
 
 func TestFindFirstHumanSeat(t *testing.T) {
@@ -219,7 +270,7 @@ func TestMatchLabel_Marshal(t *testing.T) {
 				Open:  3,
 				State: "lobby",
 			},
-			expected: `{"open":3,"state":"lobby"}`,
+			expected: `{"open":3,"state":"lobby","type":0,"spectators":0,"private":false,"tier":"","rules":""}`,
 		},
 		{
 			name: "PlayingState",
@@ -227,7 +278,20 @@ func TestMatchLabel_Marshal(t *testing.T) {
 				Open:  0,
 				State: "playing",
 			},
-			expected: `{"open":0,"state":"playing"}`,
+			expected: `{"open":0,"state":"playing","type":0,"spectators":0,"private":false,"tier":"","rules":""}`,
+		},
+		{
+			name: "PrivateTierRulesWithSpectators",
+			label: &pb.MatchLabel{
+				Open:       1,
+				State:      "lobby",
+				Type:       int32(pb.MatchType_MATCH_TYPE_CASUAL),
+				Spectators: 2,
+				Private:    true,
+				Tier:       "high_roller",
+				Rules:      "northern",
+			},
+			expected: `{"open":1,"state":"lobby","type":1,"spectators":2,"private":true,"tier":"high_roller","rules":"northern"}`,
 		},
 	}
 
@@ -484,3 +548,961 @@ func deterministicShuffle(seed int64) func([]domain.Card) []domain.Card {
 		return out
 	}
 }
+
+// testPresence is a minimal runtime.Presence.
+type testPresence struct{ userID string }
+
+func (p testPresence) GetHidden() bool                   { return false }
+func (p testPresence) GetPersistence() bool              { return false }
+func (p testPresence) GetUsername() string               { return p.userID }
+func (p testPresence) GetStatus() string                 { return "" }
+func (p testPresence) GetReason() runtime.PresenceReason { return runtime.PresenceReasonUnknown }
+func (p testPresence) GetUserId() string                 { return p.userID }
+func (p testPresence) GetSessionId() string              { return "session-" + p.userID }
+func (p testPresence) GetNodeId() string                 { return "node" }
+
+// testMatchData is a client message from a testPresence.
+type testMatchData struct {
+	testPresence
+	opCode int64
+	data   []byte
+}
+
+func (d testMatchData) GetOpCode() int64      { return d.opCode }
+func (d testMatchData) GetData() []byte       { return d.data }
+func (d testMatchData) GetReliable() bool     { return true }
+func (d testMatchData) GetReceiveTime() int64 { return 0 }
+
+// testDeck is a fixed deal in seat order, 13 cards per player; none of its hands is an instant win.
+const testDeck = "3S 3C 4C 5D 6H 8S 9C 10D JH KS AC 2D QH " +
+	"4H 4S 5S 6S 7S 8D 9D 10S JS QS KD AD 2S " +
+	"3D 3H 4D 5C 5H 6C 6D 7C 7D 7H 8C 8H 9S"
+
+// newTestMatchState returns a lobby with userIDs seated from seat 0, all connected, owned by seat 0.
+// Its Service draws server seeds from a fixed source, so games the handler starts deal the same hands on
+// every run; none of the deals the tests make from it is an instant win, which would end the game before
+// the test could look at it.
+func newTestMatchState(userIDs ...string) *MatchState {
+	state := &MatchState{
+		OwnerSeat:      0,
+		LastWinnerSeat: -1,
+		Presences:      make(map[string]runtime.Presence),
+		Bots:           make(map[string]*bot.Agent),
+		Autopilots:     make(map[string]*bot.Agent),
+		App:            app.NewService(rand.New(rand.NewSource(1))),
+	}
+	for i, userID := range userIDs {
+		state.Seats[i] = userID
+		state.Presences[userID] = testPresence{userID}
+	}
+	return state
+}
+
+// dealTestGame starts a game on state from testDeck with a base bet of 100.
+func dealTestGame(t *testing.T, state *MatchState) {
+	t.Helper()
+	deck, err := domain.ParseCards(testDeck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Game, _, err = state.App.StartGameWithDeck(state.Seats[:], state.LastWinnerSeat, 100, deck)
+	if err != nil {
+		t.Fatalf("StartGameWithDeck: %v", err)
+	}
+}
+
+func newHintTestState(t *testing.T, hints config.HintSettings) *MatchState {
+	t.Helper()
+	hand, err := domain.ParseCards("3S 3C 3D 3H 5S 9H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := newTestMatchState("user-1", "user-2")
+	state.Hints = hints
+	state.Game = &domain.Game{
+		Phase: domain.PhasePlaying,
+		Players: map[string]*domain.Player{
+			"user-1": {UserID: "user-1", Seat: 0, Hand: hand},
+			"user-2": {UserID: "user-2", Seat: 1, Hand: []domain.Card{{Rank: 12, Suit: 3}}},
+		},
+		CurrentTurn:           0,
+		LastPlayedCombination: domain.CardCombination{Type: domain.Invalid},
+	}
+	return state
+}
+
+func TestHandleRequestHint_RepliesPrivatelyWithinLimits(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newHintTestState(t, config.HintSettings{Enabled: true, CooldownSeconds: 5, MaxPerGame: 2, Suggestions: 3})
+	request := testMatchData{testPresence: testPresence{"user-1"}, opCode: int64(pb.OpCode_OP_CODE_REQUEST_HINT)}
+
+	expectHint := func(wantRemaining int32) {
+		t.Helper()
+		handler.handleRequestHint(context.Background(), state, dispatcher, noopLogger{}, request)
+		if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_HINT) {
+			t.Fatalf("tick %d: expected a hint, got opcode %d", state.Tick, dispatcher.lastOpCode)
+		}
+		event := &pb.HintEvent{}
+		if err := proto.Unmarshal(dispatcher.lastData, event); err != nil {
+			t.Fatalf("unmarshal HintEvent: %v", err)
+		}
+		if len(event.Suggestions) == 0 || len(event.Suggestions) > 3 {
+			t.Fatalf("expected 1-3 suggestions, got %d", len(event.Suggestions))
+		}
+		for _, s := range event.Suggestions {
+			if s.Pass || len(s.Cards) == 0 || len(s.Reasons) == 0 {
+				t.Errorf("leading suggestion should be a play with reasons, got %v", s)
+			}
+		}
+		if event.HintsRemaining != wantRemaining {
+			t.Errorf("hints remaining = %d, want %d", event.HintsRemaining, wantRemaining)
+		}
+	}
+	expectError := func(wantCode int32) {
+		t.Helper()
+		handler.handleRequestHint(context.Background(), state, dispatcher, noopLogger{}, request)
+		if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_ERROR) {
+			t.Fatalf("tick %d: expected an error, got opcode %d", state.Tick, dispatcher.lastOpCode)
+		}
+		event := &pb.GameErrorEvent{}
+		if err := proto.Unmarshal(dispatcher.lastData, event); err != nil {
+			t.Fatalf("unmarshal GameErrorEvent: %v", err)
+		}
+		if event.Code != wantCode {
+			t.Errorf("error code = %d (%s), want %d", event.Code, event.Message, wantCode)
+		}
+	}
+
+	state.Tick = 10
+	expectHint(1)
+	state.Tick = 14
+	expectError(429) // Cooldown
+	state.Tick = 15
+	expectHint(0)
+	state.Tick = 30
+	expectError(429) // Allowance used up
+
+	state.Game.CurrentTurn = 1
+	state.HintsUsed["user-1"] = 0
+	expectError(400) // Not the requester's turn
+}
+
+func TestHandleRequestHint_DisabledForMatchType(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newHintTestState(t, config.HintSettings{Enabled: false})
+
+	request := testMatchData{testPresence: testPresence{"user-1"}, opCode: int64(pb.OpCode_OP_CODE_REQUEST_HINT)}
+	handler.handleRequestHint(context.Background(), state, dispatcher, noopLogger{}, request)
+
+	event := &pb.GameErrorEvent{}
+	if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_ERROR) || proto.Unmarshal(dispatcher.lastData, event) != nil {
+		t.Fatalf("expected a GameErrorEvent, got opcode %d", dispatcher.lastOpCode)
+	}
+	if event.Code != 403 {
+		t.Errorf("error code = %d, want 403", event.Code)
+	}
+	if len(state.Advisors) != 0 {
+		t.Error("disabled hints should not create an advisor")
+	}
+}
+
+func TestAutopilot_PlaysDisconnectedSeatUntilRejoin(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	dealTestGame(t, state)
+	if state.Game.CurrentTurn != 0 {
+		t.Fatalf("expected seat 0 to lead, got %d", state.Game.CurrentTurn)
+	}
+
+	handler.MatchLeave(context.Background(), noopLogger{}, nil, nil, dispatcher, 1, state, []runtime.Presence{testPresence{"user-1"}})
+	if state.Seats[0] != "user-1" {
+		t.Fatalf("seat 0 should stay with the disconnected player, got %q", state.Seats[0])
+	}
+	if _, ok := state.Autopilots["user-1"]; !ok {
+		t.Fatal("expected an autopilot for user-1")
+	}
+	if state.OwnerSeat != 1 {
+		t.Errorf("owner should move to the connected player, got seat %d", state.OwnerSeat)
+	}
+	snapshot := &pb.MatchStateSnapshot{}
+	if err := proto.Unmarshal(dispatcher.lastData, snapshot); err != nil {
+		t.Fatalf("unmarshal snapshot: %v", err)
+	}
+	for _, p := range snapshot.Players {
+		if p.Autopilot != (p.UserId == "user-1") {
+			t.Errorf("player %s autopilot = %t", p.UserId, p.Autopilot)
+		}
+	}
+
+	// The autopilot leads on user-1's turn without waiting for the turn timer.
+	state.Tick = 10
+	handler.processBots(context.Background(), state, dispatcher, noopLogger{}, &mockBotBalancer{})
+	if state.Game.CurrentTurn != 1 {
+		t.Fatalf("expected the autopilot to play and pass the turn to seat 1, turn is %d", state.Game.CurrentTurn)
+	}
+	if n := len(state.Game.Players["user-1"].Hand); n >= 13 {
+		t.Errorf("expected the autopilot to play cards, hand has %d", n)
+	}
+
+	handler.MatchJoin(context.Background(), noopLogger{}, nil, nil, dispatcher, 11, state, []runtime.Presence{testPresence{"user-1"}})
+	if len(state.Autopilots) != 0 || len(state.DisconnectedUntil) != 0 {
+		t.Error("rejoining should release the autopilot and the held seat")
+	}
+	if state.Seats != [4]string{"user-1", "user-2", "", ""} {
+		t.Errorf("rejoining should restore the original seat, seats are %v", state.Seats)
+	}
+
+	// The rejoining player is resynced with their hand and the table.
+	if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_RESYNC) {
+		t.Fatalf("expected a GameResyncEvent last, got opcode %d", dispatcher.lastOpCode)
+	}
+	resync := &pb.GameResyncEvent{}
+	if err := proto.Unmarshal(dispatcher.lastData, resync); err != nil {
+		t.Fatalf("unmarshal GameResyncEvent: %v", err)
+	}
+	game := state.Game
+	if len(resync.Hand) != len(game.Players["user-1"].Hand) {
+		t.Errorf("resync hand has %d cards, want %d", len(resync.Hand), len(game.Players["user-1"].Hand))
+	}
+	if len(resync.LastPlayedCards) != len(game.LastPlayedCombination.Cards) || resync.LastPlayerToPlaySeat != 0 || resync.CurrentTurnSeat != 1 {
+		t.Errorf("resync table = %d cards by seat %d, turn %d; want the autopilot's play by seat 0, turn 1",
+			len(resync.LastPlayedCards), resync.LastPlayerToPlaySeat, resync.CurrentTurnSeat)
+	}
+	if len(resync.Discards) != len(game.Discards) {
+		t.Errorf("resync has %d discards, want %d", len(resync.Discards), len(game.Discards))
+	}
+}
+
+func TestReleaseExpiredSeats_HoldsLobbySeatForGracePeriod(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	state.ReconnectGrace = 30
+	state.Tick = 100
+
+	handler.MatchLeave(context.Background(), noopLogger{}, nil, nil, dispatcher, 100, state, []runtime.Presence{testPresence{"user-1"}})
+	if state.Seats[0] != "user-1" || state.DisconnectedUntil["user-1"] != 130 {
+		t.Fatalf("expected seat 0 held until tick 130, got seats %v, until %v", state.Seats, state.DisconnectedUntil)
+	}
+	if state.OwnerSeat != 1 {
+		t.Errorf("owner should move to the connected player, got seat %d", state.OwnerSeat)
+	}
+	if _, ok := dispatcher.sent[int64(pb.OpCode_OP_CODE_PLAYER_LEFT)]; ok {
+		t.Error("PlayerLeftEvent sent for a held seat")
+	}
+
+	state.Tick = 129
+	if handler.releaseExpiredSeats(state, dispatcher, noopLogger{}) {
+		t.Fatal("seat released before the grace period ended")
+	}
+
+	// An autopiloted seat stays held until its game ends.
+	state.Tick = 130
+	state.Autopilots = map[string]*bot.Agent{"user-1": bot.NewAutopilot(domain.PlayerView{Seat: 0, UserID: "user-1"})}
+	if handler.releaseExpiredSeats(state, dispatcher, noopLogger{}) {
+		t.Fatal("autopiloted seat released mid-game")
+	}
+
+	state.Autopilots = nil
+	if !handler.releaseExpiredSeats(state, dispatcher, noopLogger{}) || state.Seats[0] != "" || len(state.DisconnectedUntil) != 0 {
+		t.Errorf("expected seat 0 freed after the grace period, got seats %v", state.Seats)
+	}
+	left := &pb.PlayerLeftEvent{}
+	if err := proto.Unmarshal(dispatcher.sent[int64(pb.OpCode_OP_CODE_PLAYER_LEFT)], left); err != nil {
+		t.Fatalf("unmarshal PlayerLeftEvent: %v", err)
+	}
+	if left.Seat != 0 || left.UserId != "user-1" {
+		t.Errorf("PlayerLeftEvent = %v, want seat 0 user-1", left)
+	}
+}
+
+func TestSpectator_WatchesFullTableWithoutSeat(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	dealTestGame(t, state)
+	state.Seats = [4]string{"user-1", "user-2", "user-3", "user-4"}
+	state.SpectatorsAllowed = true
+	ctx := context.Background()
+	watcher := testPresence{"watcher"}
+
+	if _, ok, reason := handler.MatchJoinAttempt(ctx, noopLogger{}, nil, nil, dispatcher, 1, state, watcher, nil); ok {
+		t.Fatal("a player should not fit at a full table")
+	} else if reason != "Match full" {
+		t.Errorf("reason = %q, want Match full", reason)
+	}
+	spectate := map[string]string{"role": roleSpectator}
+	if _, ok, reason := handler.MatchJoinAttempt(ctx, noopLogger{}, nil, nil, dispatcher, 1, state, watcher, spectate); !ok {
+		t.Fatalf("spectator rejected: %s", reason)
+	}
+	handler.MatchJoin(ctx, noopLogger{}, nil, nil, dispatcher, 1, state, []runtime.Presence{watcher})
+
+	if state.seatOf("watcher") != -1 || len(state.Spectators) != 1 {
+		t.Fatalf("expected watcher to spectate without a seat, seats %v", state.Seats)
+	}
+	label := &pb.MatchLabel{}
+	if err := protojson.Unmarshal([]byte(dispatcher.lastLabel), label); err != nil || label.Spectators != 1 {
+		t.Errorf("label %s should count 1 spectator (err %v)", dispatcher.lastLabel, err)
+	}
+
+	// Joining a running game: the spectator gets the public table, never a hand.
+	if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_RESYNC) || len(dispatcher.lastPresences) != 1 || dispatcher.lastPresences[0].GetUserId() != "watcher" {
+		t.Fatalf("expected a private resync for the spectator, got opcode %d to %v", dispatcher.lastOpCode, dispatcher.lastPresences)
+	}
+	resync := &pb.GameResyncEvent{}
+	if err := proto.Unmarshal(dispatcher.lastData, resync); err != nil {
+		t.Fatal(err)
+	}
+	if len(resync.Hand) != 0 {
+		t.Errorf("spectator resync leaked a hand: %v", resync.Hand)
+	}
+
+	// Dealt hands go to their owner only.
+	handler.broadcastEvent(ctx, state, dispatcher, noopLogger{}, app.Event{
+		Kind:       app.EventGameStarted,
+		Payload:    app.GameStartedPayload{Hand: state.Game.Players["user-1"].Hand},
+		Recipients: []string{"user-1"},
+	})
+	for _, p := range dispatcher.lastPresences {
+		if p.GetUserId() != "user-1" {
+			t.Errorf("GameStartedEvent sent to %s", p.GetUserId())
+		}
+	}
+}
+
+func TestSpectator_OwnerDisablesSpectating(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	dealTestGame(t, state)
+	state.SpectatorsAllowed = true
+	handler.addSpectator(state, testPresence{"watcher"})
+
+	disable, _ := proto.Marshal(&pb.SetSpectatingRequest{Allowed: false})
+	notOwner := testMatchData{testPresence: testPresence{"user-2"}, opCode: int64(pb.OpCode_OP_CODE_SET_SPECTATING), data: disable}
+	handler.handleSetSpectating(context.Background(), state, dispatcher, noopLogger{}, notOwner)
+	if !state.SpectatorsAllowed || dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_ERROR) {
+		t.Fatal("only the owner may change spectating")
+	}
+
+	owner := notOwner
+	owner.testPresence = testPresence{"user-1"}
+	handler.handleSetSpectating(context.Background(), state, dispatcher, noopLogger{}, owner)
+	if state.SpectatorsAllowed || len(state.Spectators) != 0 {
+		t.Fatalf("expected spectating disabled and spectators removed, got allowed=%t, %d spectators", state.SpectatorsAllowed, len(state.Spectators))
+	}
+	if len(dispatcher.kicked) != 1 || dispatcher.kicked[0].GetUserId() != "watcher" {
+		t.Errorf("expected the spectator to be kicked, kicked %v", dispatcher.kicked)
+	}
+	if _, ok, _ := handler.MatchJoinAttempt(context.Background(), noopLogger{}, nil, nil, dispatcher, 1, state, testPresence{"late"}, map[string]string{"role": roleSpectator}); ok {
+		t.Error("spectators should be rejected once spectating is disabled")
+	}
+}
+
+func TestSeatSpectators_FillsFreeSeatsBetweenGames(t *testing.T) {
+	handler := &matchHandler{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+	state.Game = &domain.Game{Phase: domain.PhasePlaying}
+	handler.addSpectator(state, testPresence{"first"})
+	handler.addSpectator(state, testPresence{"second"})
+
+	if handler.seatSpectators(context.Background(), state, noopLogger{}) {
+		t.Fatal("spectators must not be seated mid-game")
+	}
+
+	state.Game = nil
+	if !handler.seatSpectators(context.Background(), state, noopLogger{}) {
+		t.Fatal("expected a spectator to take the free seat")
+	}
+	if state.Seats[3] != "first" || state.Presences["first"] == nil {
+		t.Errorf("expected the first spectator in seat 3, seats %v", state.Seats)
+	}
+	if len(state.SpectatorQueue) != 1 || state.SpectatorQueue[0] != "second" {
+		t.Errorf("expected second to keep waiting, queue %v", state.SpectatorQueue)
+	}
+}
+
+func TestSeatSpectators_SkipsSpectatorsOutsideTierLimits(t *testing.T) {
+	handler := &matchHandler{}
+	state := newTestMatchState("user-1", "user-2")
+	state.Tier = "casual"
+	state.Economy = &mockEconomy{balances: map[string]int64{"user-1": 5000, "user-2": 5000, "poor": 50, "regular": 5000}}
+	handler.addSpectator(state, testPresence{"poor"})
+	handler.addSpectator(state, testPresence{"unknown"}) // Wallet error
+	handler.addSpectator(state, testPresence{"regular"})
+
+	if !handler.seatSpectators(context.Background(), state, noopLogger{}) {
+		t.Fatal("expected the eligible spectator to be seated")
+	}
+	if state.Seats[2] != "regular" || state.Seats[3] != "" {
+		t.Errorf("expected only regular seated, seats %v", state.Seats)
+	}
+	if len(state.SpectatorQueue) != 2 || state.SpectatorQueue[0] != "poor" || state.SpectatorQueue[1] != "unknown" {
+		t.Errorf("expected ineligible spectators to keep their place in line, queue %v", state.SpectatorQueue)
+	}
+	if _, ok := state.Spectators["poor"]; !ok {
+		t.Error("ineligible spectator should keep watching")
+	}
+}
+
+func TestStartCountdown_StartsGameOnceEnoughPlayersAreReady(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	setReady := func(userID string, ready bool) {
+		data, _ := proto.Marshal(&pb.SetReadyRequest{Ready: ready, ClientSalt: userID + "-salt"})
+		handler.handleSetReady(context.Background(), state, dispatcher, noopLogger{},
+			testMatchData{testPresence: testPresence{userID}, opCode: int64(pb.OpCode_OP_CODE_SET_READY), data: data})
+	}
+
+	setReady("user-1", true)
+	handler.tickStartCountdown(context.Background(), state, dispatcher, noopLogger{})
+	if state.StartCountdown != 0 {
+		t.Fatalf("one ready player must not start the countdown, got %d", state.StartCountdown)
+	}
+
+	setReady("user-2", true)
+	handler.tickStartCountdown(context.Background(), state, dispatcher, noopLogger{})
+	if state.StartCountdown <= 0 {
+		t.Fatal("expected the countdown to start once both players are ready")
+	}
+	snapshot := &pb.MatchStateSnapshot{}
+	if err := proto.Unmarshal(dispatcher.lastData, snapshot); err != nil {
+		t.Fatalf("unmarshal snapshot: %v", err)
+	}
+	if snapshot.StartCountdownSeconds != state.StartCountdown {
+		t.Errorf("snapshot countdown = %d, want %d", snapshot.StartCountdownSeconds, state.StartCountdown)
+	}
+
+	// Unreadying cancels; readying again restarts from the top.
+	setReady("user-2", false)
+	handler.tickStartCountdown(context.Background(), state, dispatcher, noopLogger{})
+	if state.StartCountdown != 0 {
+		t.Fatalf("expected the countdown to be cancelled, got %d", state.StartCountdown)
+	}
+	setReady("user-2", true)
+	for i := 0; state.Game == nil && i < 100; i++ {
+		handler.tickStartCountdown(context.Background(), state, dispatcher, noopLogger{})
+	}
+	if state.Game == nil {
+		t.Fatal("expected the game to start when the countdown reached zero")
+	}
+	if len(state.Ready) != 0 || state.StartCountdown != 0 {
+		t.Errorf("starting should reset readiness, ready %v, countdown %d", state.Ready, state.StartCountdown)
+	}
+	if state.Game.Seed == nil || state.Game.Seed.ClientSalt != "user-1-salt|user-2-salt" {
+		t.Errorf("deal seed %+v should be salted by both ready players", state.Game.Seed)
+	}
+}
+
+func TestStartCountdown_DealsInOnlyReadyPlayers(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+	state.Ready = map[string]bool{"user-1": true, "user-3": true}
+	state.ClientSalts = map[string]string{"user-1": "alpha", "user-2": "beta", "user-3": "gamma"}
+
+	for i := 0; i < 100 && state.Game == nil; i++ {
+		handler.tickStartCountdown(context.Background(), state, dispatcher, noopLogger{})
+	}
+	if state.Game == nil {
+		t.Fatal("expected the countdown to start a game")
+	}
+	if _, ok := state.Game.Players["user-2"]; ok || len(state.Game.Players) != 2 {
+		t.Errorf("only ready players should be dealt in, got %d players", len(state.Game.Players))
+	}
+	if state.Seats[1] != "user-2" {
+		t.Errorf("the unready player keeps their seat, seats %v", state.Seats)
+	}
+	if state.Game.Seed.ClientSalt != "alpha|gamma" {
+		t.Errorf("deal salt = %q, want only the ready players' salts", state.Game.Seed.ClientSalt)
+	}
+}
+
+func TestHandleSetReady_RejectedDuringGame(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	dealTestGame(t, state)
+	data, _ := proto.Marshal(&pb.SetReadyRequest{Ready: true})
+
+	handler.handleSetReady(context.Background(), state, dispatcher, noopLogger{},
+		testMatchData{testPresence: testPresence{"user-1"}, opCode: int64(pb.OpCode_OP_CODE_SET_READY), data: data})
+	if state.Ready["user-1"] {
+		t.Fatal("players must not ready up while a game is running")
+	}
+	if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_ERROR) {
+		t.Errorf("expected an error reply, got opcode %d", dispatcher.lastOpCode)
+	}
+}
+
+func voteRematch(handler *matchHandler, state *MatchState, dispatcher *mockDispatcher, userID string) {
+	data, _ := proto.Marshal(&pb.RequestNewGameRequest{ClientSalt: userID + "-salt"})
+	handler.handleRequestNewGame(context.Background(), state, dispatcher, noopLogger{},
+		testMatchData{testPresence: testPresence{userID}, opCode: int64(pb.OpCode_OP_CODE_REQUEST_NEW_GAME), data: data})
+}
+
+func TestRematchVote_StartsWhenEveryoneAgrees(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+	state.LastWinnerSeat = 1
+	state.Tick = 100
+
+	handler.openRematchVote(state, dispatcher, noopLogger{})
+	voteRematch(handler, state, dispatcher, "user-1")
+	voteRematch(handler, state, dispatcher, "user-2")
+
+	progress := &pb.RematchVoteEvent{}
+	if err := proto.Unmarshal(dispatcher.lastData, progress); err != nil {
+		t.Fatalf("unmarshal RematchVoteEvent: %v", err)
+	}
+	if dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_REMATCH_VOTE) ||
+		len(progress.VotedSeats) != 2 || len(progress.WaitingSeats) != 1 || progress.WaitingSeats[0] != 2 {
+		t.Fatalf("unexpected vote progress %v (opcode %d)", progress, dispatcher.lastOpCode)
+	}
+
+	handler.tickRematchVote(context.Background(), state, dispatcher, noopLogger{})
+	if state.Game != nil {
+		t.Fatal("the rematch must wait for every seat to vote")
+	}
+
+	voteRematch(handler, state, dispatcher, "user-3")
+	handler.tickRematchVote(context.Background(), state, dispatcher, noopLogger{})
+	if state.Game == nil {
+		t.Fatal("expected the rematch to start once everyone voted")
+	}
+	if state.Game.CurrentTurn != 1 {
+		t.Errorf("expected the last winner (seat 1) to lead, turn is %d", state.Game.CurrentTurn)
+	}
+	if len(state.Game.Players) != 3 || state.RematchUntil != 0 {
+		t.Errorf("expected all three players in the rematch and the vote closed")
+	}
+	if state.Game.Seed == nil || state.Game.Seed.ClientSalt != "user-1-salt|user-2-salt|user-3-salt" {
+		t.Errorf("deal seed %+v should be salted by every voter", state.Game.Seed)
+	}
+}
+
+func TestRematchVote_ReleasesNonVotersOnTimeout(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+	state.LastWinnerSeat = 1
+	state.Tick = 100
+
+	handler.openRematchVote(state, dispatcher, noopLogger{})
+	voteRematch(handler, state, dispatcher, "user-1")
+	voteRematch(handler, state, dispatcher, "user-2")
+	// A spectator taking the free seat never saw the vote and is not held to it.
+	state.Seats[3] = "late"
+	state.Presences["late"] = testPresence{"late"}
+
+	state.Tick = state.RematchUntil
+	if !handler.tickRematchVote(context.Background(), state, dispatcher, noopLogger{}) {
+		t.Fatal("expected the non-voter's seat to be released")
+	}
+	if state.Seats[2] != "" || len(dispatcher.kicked) != 1 || dispatcher.kicked[0].GetUserId() != "user-3" {
+		t.Errorf("expected user-3 to be removed, seats %v, kicked %v", state.Seats, dispatcher.kicked)
+	}
+	if state.Seats[3] != "late" {
+		t.Errorf("the player seated after the vote opened should keep their seat, seats %v", state.Seats)
+	}
+	if state.Game == nil || len(state.Game.Players) != 3 || state.Game.Players["user-3"] != nil {
+		t.Fatal("expected the voters and the late arrival to start the rematch without user-3")
+	}
+}
+
+func TestHandleRequestNewGame_RejectedWithoutOpenVote(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+
+	voteRematch(handler, state, dispatcher, "user-1")
+	if state.RematchVotes["user-1"] || dispatcher.lastOpCode != int64(pb.OpCode_OP_CODE_GAME_ERROR) {
+		t.Fatal("expected a vote outside the rematch window to be rejected")
+	}
+}
+
+// leavingPresence is a testPresence that left the match on purpose rather than disconnecting.
+type leavingPresence struct{ testPresence }
+
+func (p leavingPresence) GetReason() runtime.PresenceReason { return runtime.PresenceReasonLeave }
+
+func TestMatchLeave_LeavingOnOwnTurnForfeits(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+	dealTestGame(t, state)
+	if state.Game.CurrentTurn != 0 {
+		t.Fatalf("expected seat 0 to lead, got %d", state.Game.CurrentTurn)
+	}
+
+	handler.MatchLeave(context.Background(), noopLogger{}, nil, nil, dispatcher, 1, state, []runtime.Presence{leavingPresence{testPresence{"user-1"}}})
+
+	left := &pb.PlayerLeftEvent{}
+	if err := proto.Unmarshal(dispatcher.sent[int64(pb.OpCode_OP_CODE_PLAYER_LEFT)], left); err != nil {
+		t.Fatalf("unmarshal PlayerLeftEvent: %v", err)
+	}
+	if left.Seat != 0 || left.UserId != "user-1" {
+		t.Errorf("PlayerLeftEvent = %v, want seat 0 user-1", left)
+	}
+	if len(state.Autopilots) != 0 || !state.Game.Players["user-1"].Forfeited {
+		t.Fatal("leaving on purpose should forfeit rather than engage an autopilot")
+	}
+	if state.Game.CurrentTurn != 1 {
+		t.Errorf("expected the turn to move to seat 1, got %d", state.Game.CurrentTurn)
+	}
+	if _, ok := dispatcher.sent[int64(pb.OpCode_OP_CODE_TURN_PASSED)]; !ok {
+		t.Error("expected a TurnPassedEvent for the forfeited turn")
+	}
+	if state.Seats[0] != "user-1" || state.OwnerSeat != 1 {
+		t.Errorf("the seat stays taken until the game ends and ownership moves on; seats %v, owner %d", state.Seats, state.OwnerSeat)
+	}
+	if _, ok, _ := handler.MatchJoinAttempt(context.Background(), noopLogger{}, nil, nil, nil, 1, state, testPresence{"user-1"}, nil); ok {
+		t.Error("a forfeited player must not rejoin the running game")
+	}
+
+	// The last opponent leaving ends the game; both leavers' seats free up.
+	handler.MatchLeave(context.Background(), noopLogger{}, nil, nil, dispatcher, 2, state, []runtime.Presence{leavingPresence{testPresence{"user-3"}}})
+	if state.Game != nil {
+		t.Fatal("expected the game to end with one player holding cards")
+	}
+	ended := &pb.GameEndedEvent{}
+	if err := proto.Unmarshal(dispatcher.sent[int64(pb.OpCode_OP_CODE_GAME_ENDED)], ended); err != nil {
+		t.Fatalf("unmarshal GameEndedEvent: %v", err)
+	}
+	if want := []int32{1, 2, 0}; len(ended.FinishOrderSeats) != 3 || ended.FinishOrderSeats[0] != want[0] ||
+		ended.FinishOrderSeats[1] != want[1] || ended.FinishOrderSeats[2] != want[2] {
+		t.Errorf("finish order = %v, want %v", ended.FinishOrderSeats, want)
+	}
+	if state.Seats != [4]string{"", "user-2", "", ""} {
+		t.Errorf("expected only user-2 seated after the game, seats %v", state.Seats)
+	}
+}
+
+func TestTier_ShownInLabelAndSnapshot(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := &MatchState{Seats: [4]string{"user-1", "", "", ""}, OwnerSeat: 0, Tier: "high_roller"}
+
+	handler.updateLabel(state, dispatcher, noopLogger{})
+	label := &pb.MatchLabel{}
+	if err := protojson.Unmarshal([]byte(dispatcher.lastLabel), label); err != nil || label.Tier != "high_roller" {
+		t.Errorf("label %s should carry the tier (err %v)", dispatcher.lastLabel, err)
+	}
+
+	handler.broadcastMatchState(context.Background(), state, dispatcher, noopLogger{})
+	snapshot := &pb.MatchStateSnapshot{}
+	if err := proto.Unmarshal(dispatcher.lastData, snapshot); err != nil {
+		t.Fatalf("unmarshal snapshot: %v", err)
+	}
+	if snapshot.Tier != "high_roller" || snapshot.BaseBet != 10000 {
+		t.Errorf("snapshot tier %q base bet %d, want high_roller at 10000", snapshot.Tier, snapshot.BaseBet)
+	}
+}
+
+func TestFindMatch_RejectsUnknownTier(t *testing.T) {
+	nk := newFakeRoomNakama()
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "user-1")
+
+	_, err := RpcFindMatch(ctx, noopLogger{}, nil, nk, `{"tier": "no_such_tier"}`)
+	if err == nil || !strings.Contains(err.Error(), `"app_code":1004`) {
+		t.Fatalf("RpcFindMatch error = %v, want ERROR_CODE_TIER_INVALID", err)
+	}
+	if len(nk.matches) != 0 {
+		t.Fatalf("find_match created a match for an unknown tier: %v", nk.matches)
+	}
+}
+
+func TestFindMatch_SearchesAndCreatesRequestedTier(t *testing.T) {
+	nk := newFakeRoomNakama()
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "user-1")
+
+	raw, err := RpcFindMatch(ctx, noopLogger{}, nil, nk, `{"tier": "ranked"}`)
+	if err != nil {
+		t.Fatalf("RpcFindMatch error: %v", err)
+	}
+	if !strings.Contains(nk.lastQuery, "+label."+MatchLabelKey_Tier+":ranked") {
+		t.Errorf("find_match query %q does not filter on the tier", nk.lastQuery)
+	}
+	var matchID string
+	if err := json.Unmarshal([]byte(raw), &matchID); err != nil || nk.matches[matchID]["tier"] != "ranked" {
+		t.Errorf("expected a ranked match to be created, got %v (err %v)", nk.matches[matchID], err)
+	}
+}
+
+func TestFindMatch_SearchesAndCreatesRequestedRules(t *testing.T) {
+	nk := newFakeRoomNakama()
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "user-1")
+
+	if _, err := RpcFindMatch(ctx, noopLogger{}, nil, nk, `{"rules": "western"}`); err == nil || !strings.Contains(err.Error(), `"app_code":1007`) {
+		t.Fatalf("RpcFindMatch error = %v, want ERROR_CODE_RULES_INVALID", err)
+	}
+
+	raw, err := RpcFindMatch(ctx, noopLogger{}, nil, nk, `{}`)
+	if err != nil {
+		t.Fatalf("RpcFindMatch error: %v", err)
+	}
+	if !strings.Contains(nk.lastQuery, "+label."+MatchLabelKey_Rules+":"+domain.RuleSetSouthern) {
+		t.Errorf("find_match query %q should default to Southern rules", nk.lastQuery)
+	}
+
+	raw, err = RpcFindMatch(ctx, noopLogger{}, nil, nk, `{"rules": "northern"}`)
+	if err != nil {
+		t.Fatalf("RpcFindMatch error: %v", err)
+	}
+	if !strings.Contains(nk.lastQuery, "+label."+MatchLabelKey_Rules+":"+domain.RuleSetNorthern) {
+		t.Errorf("find_match query %q does not filter on the rules", nk.lastQuery)
+	}
+	var matchID string
+	if err := json.Unmarshal([]byte(raw), &matchID); err != nil || nk.matches[matchID]["rules"] != domain.RuleSetNorthern {
+		t.Errorf("expected a Northern match to be created, got %v (err %v)", nk.matches[matchID], err)
+	}
+}
+
+func TestTierBalanceError(t *testing.T) {
+	botID := bot.GetBotIdentity(0).UserID
+	economy := &mockEconomy{balances: map[string]int64{"poor": 50, "regular": 5000, "rich": 900000, botID: 0}}
+	tier := config.BetTier{ID: "casual", BaseBet: 100, MinBalance: 1000, MaxBalance: 50000}
+
+	tests := []struct {
+		userID string
+		tier   config.BetTier
+		want   pb.ErrorCode
+	}{
+		{"poor", tier, pb.ErrorCode_ERROR_CODE_BALANCE_TOO_LOW},
+		{"regular", tier, pb.ErrorCode_ERROR_CODE_UNSPECIFIED},
+		{"rich", tier, pb.ErrorCode_ERROR_CODE_BALANCE_TOO_HIGH},
+		{botID, tier, pb.ErrorCode_ERROR_CODE_UNSPECIFIED},                        // Bots top up on their own
+		{"poor", config.BetTier{ID: "free"}, pb.ErrorCode_ERROR_CODE_UNSPECIFIED}, // No limits
+	}
+	for _, test := range tests {
+		got, err := tierBalanceError(context.Background(), economy, test.tier, test.userID)
+		if err != nil || got != test.want {
+			t.Errorf("tierBalanceError(%s, %s) = %v, %v; want %v", test.userID, test.tier.ID, got, err, test.want)
+		}
+	}
+
+	if _, err := tierBalanceError(context.Background(), economy, tier, "unknown"); err == nil {
+		t.Error("expected a wallet error to be reported")
+	}
+}
+
+func TestStakeEscrow_CapsPayoutsToWhatLosersCanCover(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2")
+	dealTestGame(t, state)
+	economy := &mockEconomy{balances: map[string]int64{"user-1": 10000, "user-2": 150}}
+	state.Economy = economy
+	store := &mockEscrowStore{}
+	state.EscrowStore = store
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_MATCH_ID, "match-1")
+
+	if unheld := handler.holdStakes(ctx, state, state.Game, noopLogger{}); len(unheld) != 0 {
+		t.Fatalf("unheld stakes = %v, want none", unheld)
+	}
+	stake := state.Game.MaxLoss(0)
+	if state.Escrow.Stakes["user-1"] != stake || state.Escrow.Stakes["user-2"] != 150 {
+		t.Fatalf("stakes = %v, want user-1 %d and all of user-2's 150", state.Escrow.Stakes, stake)
+	}
+	if held := store.records["match-1"].Stakes; held["user-1"] != stake || held["user-2"] != 150 {
+		t.Errorf("persisted stakes = %v, want the held stakes", held)
+	}
+	if economy.balances["user-1"] != 10000-stake || economy.balances["user-2"] != 0 {
+		t.Fatalf("balances after escrow = %v", economy.balances)
+	}
+
+	// user-2 owes 100 for a chop and 100 at the end, but only 150 is held.
+	handler.broadcastEvent(ctx, state, dispatcher, noopLogger{}, app.Event{
+		Kind:    app.EventPigChopped,
+		Payload: app.PigChoppedPayload{BalanceChanges: map[string]int64{"user-1": 100, "user-2": -100}},
+	})
+	if economy.balances["user-2"] != 0 {
+		t.Fatalf("chop paid out before the game ended: %v", economy.balances)
+	}
+	if changes := store.records["match-1"].Changes; changes["user-2"] != -100 {
+		t.Errorf("persisted chop changes = %v, want user-2 -100", changes)
+	}
+	handler.broadcastEvent(ctx, state, dispatcher, noopLogger{}, app.Event{
+		Kind:    app.EventGameEnded,
+		Payload: app.GameEndedPayload{FinishOrderSeats: []int{0, 1}, BalanceChanges: map[string]int64{"user-1": 100, "user-2": -100}},
+	})
+
+	if economy.balances["user-1"] != 10150 || economy.balances["user-2"] != 0 {
+		t.Errorf("balances after settlement = %v, want user-1 10150 and user-2 0", economy.balances)
+	}
+	ended := &pb.GameEndedEvent{}
+	if err := proto.Unmarshal(dispatcher.sent[int64(pb.OpCode_OP_CODE_GAME_ENDED)], ended); err != nil {
+		t.Fatalf("unmarshal GameEndedEvent: %v", err)
+	}
+	if ended.BalanceChanges["user-1"] != 50 || ended.BalanceChanges["user-2"] != -50 {
+		t.Errorf("reported end changes = %v, want the capped remainder after the chop", ended.BalanceChanges)
+	}
+	// The cap is itemized so the penalties add up to the reported changes.
+	wantCaps := []*pb.SettlementPenalty{
+		{PayerSeat: 0, PayeeSeat: -1, Reason: domain.PenaltyStakeCap, Amount: 50},
+		{PayerSeat: -1, PayeeSeat: 1, Reason: domain.PenaltyStakeCap, Amount: 50},
+	}
+	if len(ended.Penalties) != len(wantCaps) {
+		t.Fatalf("penalties = %v, want the two stake cap items", ended.Penalties)
+	}
+	for i, want := range wantCaps {
+		if !proto.Equal(ended.Penalties[i], want) {
+			t.Errorf("penalty %d = %v, want %v", i, ended.Penalties[i], want)
+		}
+	}
+	if state.Escrow != nil || len(store.records) != 0 {
+		t.Errorf("escrow should be cleared once the game settles, persisted %v", store.records)
+	}
+}
+
+// payoutFailingEconomy rejects every wallet update made for one of the given reasons.
+type payoutFailingEconomy struct {
+	mockEconomy
+	failReasons map[string]bool
+	attempts    map[string]int
+}
+
+func (pe *payoutFailingEconomy) UpdateBalances(ctx context.Context, updates []ports.WalletUpdate) error {
+	for _, update := range updates {
+		reason, _ := update.Metadata["reason"].(string)
+		if pe.failReasons[reason] {
+			if pe.attempts == nil {
+				pe.attempts = make(map[string]int)
+			}
+			pe.attempts[reason]++
+			return errors.New("wallet unavailable")
+		}
+	}
+	return pe.mockEconomy.UpdateBalances(ctx, updates)
+}
+
+func TestStakeEscrow_ReturnsStakesWhenSettlementFails(t *testing.T) {
+	tests := []struct {
+		name        string
+		failReasons map[string]bool
+		wantKept    bool // Whether the persisted escrow is kept for recovery
+	}{
+		{"settlement fails", map[string]bool{"game_settlement": true}, false},
+		{"settlement and return fail", map[string]bool{"game_settlement": true, "stake_release": true}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &matchHandler{}
+			dispatcher := &mockDispatcher{}
+			state := newTestMatchState("user-1", "user-2")
+			dealTestGame(t, state)
+			economy := &payoutFailingEconomy{
+				mockEconomy: mockEconomy{balances: map[string]int64{"user-1": 10000, "user-2": 10000}},
+				failReasons: test.failReasons,
+			}
+			state.Economy = economy
+			store := &mockEscrowStore{}
+			state.EscrowStore = store
+			ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_MATCH_ID, "match-1")
+
+			if unheld := handler.holdStakes(ctx, state, state.Game, noopLogger{}); len(unheld) != 0 {
+				t.Fatalf("unheld stakes = %v, want none", unheld)
+			}
+			handler.broadcastEvent(ctx, state, dispatcher, noopLogger{}, app.Event{
+				Kind:    app.EventPigChopped,
+				Payload: app.PigChoppedPayload{BalanceChanges: map[string]int64{"user-1": 100, "user-2": -100}},
+			})
+			handler.broadcastEvent(ctx, state, dispatcher, noopLogger{}, app.Event{
+				Kind:    app.EventGameEnded,
+				Payload: app.GameEndedPayload{FinishOrderSeats: []int{0, 1}, BalanceChanges: map[string]int64{"user-1": 200, "user-2": -200}},
+			})
+
+			if economy.attempts["game_settlement"] != settleAttempts {
+				t.Errorf("settlement tried %d times, want %d", economy.attempts["game_settlement"], settleAttempts)
+			}
+			_, kept := store.records["match-1"]
+			if kept != test.wantKept {
+				t.Errorf("persisted escrow kept = %t, want %t", kept, test.wantKept)
+			}
+			if !test.wantKept && (economy.balances["user-1"] != 10000 || economy.balances["user-2"] != 10000) {
+				t.Errorf("balances = %v, want every stake returned", economy.balances)
+			}
+			ended := &pb.GameEndedEvent{}
+			if err := proto.Unmarshal(dispatcher.sent[int64(pb.OpCode_OP_CODE_GAME_ENDED)], ended); err != nil {
+				t.Fatalf("unmarshal GameEndedEvent: %v", err)
+			}
+			if ended.BalanceChanges["user-1"] != -100 || ended.BalanceChanges["user-2"] != 100 || len(ended.Penalties) != 0 {
+				t.Errorf("reported end changes = %v, want the chop undone", ended.BalanceChanges)
+			}
+		})
+	}
+}
+
+func TestRecoverStakes_ReturnsOrphanedStakes(t *testing.T) {
+	economy := &mockEconomy{balances: map[string]int64{"user-1": 100, "user-2": 100, "user-3": 100}}
+	store := &mockEscrowStore{records: map[string]ports.HeldStakes{
+		"match-1": {Stakes: map[string]int64{"user-1": 500, "user-2": 300}, Changes: map[string]int64{"user-1": 100, "user-2": -100}},
+		"match-2": {Stakes: map[string]int64{"user-3": 700}},
+	}}
+
+	recoverStakes(context.Background(), noopLogger{}, store, economy)
+
+	want := map[string]int64{"user-1": 600, "user-2": 400, "user-3": 800}
+	for userID, balance := range want {
+		if economy.balances[userID] != balance {
+			t.Errorf("%s balance = %d, want %d", userID, economy.balances[userID], balance)
+		}
+	}
+	if len(store.records) != 0 {
+		t.Errorf("records left after recovery: %v", store.records)
+	}
+}
+
+// stakeFailingEconomy refuses to take a stake from one user.
+type stakeFailingEconomy struct {
+	mockEconomy
+	failUser string
+}
+
+func (se *stakeFailingEconomy) UpdateBalances(ctx context.Context, updates []ports.WalletUpdate) error {
+	for _, update := range updates {
+		if update.UserID == se.failUser && update.Amount < 0 {
+			return errors.New("wallet unavailable")
+		}
+	}
+	return se.mockEconomy.UpdateBalances(ctx, updates)
+}
+
+func TestStartGame_SitsOutPlayersWhoseStakeCannotBeHeld(t *testing.T) {
+	handler := &matchHandler{}
+	dispatcher := &mockDispatcher{}
+	state := newTestMatchState("user-1", "user-2", "user-3")
+	economy := &stakeFailingEconomy{
+		mockEconomy: mockEconomy{balances: map[string]int64{"user-1": 10000, "user-2": 10000, "user-3": 10000}},
+		failUser:    "user-3",
+	}
+	state.Economy = economy
+
+	if !handler.startGame(context.Background(), state, dispatcher, noopLogger{}, "", state.connectedSeats()) {
+		t.Fatal("expected the game to start without user-3")
+	}
+	if _, ok := state.Game.Players["user-3"]; ok || len(state.Game.Players) != 2 {
+		t.Errorf("user-3 should sit out, got %d players", len(state.Game.Players))
+	}
+	if _, ok := state.Escrow.Stakes["user-3"]; ok {
+		t.Errorf("stakes = %v, want none for user-3", state.Escrow.Stakes)
+	}
+	for _, userID := range []string{"user-1", "user-2"} {
+		stake := state.Escrow.Stakes[userID]
+		if stake <= 0 || economy.balances[userID] != 10000-stake {
+			t.Errorf("%s: stake %d, balance %d; want one stake held", userID, stake, economy.balances[userID])
+		}
+	}
+	if economy.balances["user-3"] != 10000 {
+		t.Errorf("user-3 balance = %d, want it untouched", economy.balances["user-3"])
+	}
+	gameErr := &pb.GameErrorEvent{}
+	if err := proto.Unmarshal(dispatcher.sent[int64(pb.OpCode_OP_CODE_GAME_ERROR)], gameErr); err != nil || gameErr.Code != 503 {
+		t.Errorf("expected user-3 to be told the balance was unavailable, got %v (%v)", gameErr, err)
+	}
+}
@@ -0,0 +1,327 @@
+package nakama
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tienlen/internal/config"
+	"tienlen/internal/domain"
+	pb "tienlen/proto"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	privateRoomCollection = "private_rooms"                    // System-owned storage objects keyed by room code
+	roomCodeLength        = 6                                  // Characters in a room code
+	roomCodeAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 32 characters without the lookalikes 0/O and 1/I
+	roomCodeAttempts      = 5                                  // Fresh codes tried before giving up on a collision
+	roomReservationTTL    = 60                                 // Seconds after which a reservation without a match is left over from a failed create
+	minRoomTurnSeconds    = 10                                 // Shortest turn an owner may choose
+	maxRoomTurnSeconds    = 60                                 // Longest turn an owner may choose
+	maxRoomPasswordLength = 32
+)
+
+// Nakama RPC errors use gRPC status codes.
+const (
+	invalidArgumentCode    = 3
+	notFoundCode           = 5
+	failedPreconditionCode = 9
+)
+
+// privateRoomSettings are the owner's choices for a private room.
+type privateRoomSettings struct {
+	Tier                string `json:"tier"`                  // Bet tier ID; empty means the default tier
+	Rules               string `json:"rules"`                 // Rule set name (see domain.RuleSetByName); empty means Southern
+	TurnDurationSeconds int    `json:"turn_duration_seconds"` // 0 means the configured duration
+	BotsEnabled         bool   `json:"bots_enabled"`
+	Password            string `json:"password"` // Empty for a room anyone with the code can join
+}
+
+// privateRoomEntry is the stored value that resolves a room code.
+type privateRoomEntry struct {
+	MatchID          string `json:"match_id"`
+	PasswordRequired bool   `json:"password_required"`
+	ReservedAt       int64  `json:"reserved_at,omitempty"` // Unix seconds a create reserved the code; only set until MatchID is
+}
+
+// validate checks the settings against the game configuration.
+func (s privateRoomSettings) validate() error {
+	if s.Tier != "" && !config.HasTier(s.Tier) {
+		return fmt.Errorf("unknown tier %q", s.Tier)
+	}
+	if _, err := domain.RuleSetByName(s.Rules, domain.RuleOptions{}); err != nil {
+		return fmt.Errorf("unknown rule set %q", s.Rules)
+	}
+	if s.TurnDurationSeconds != 0 && (s.TurnDurationSeconds < minRoomTurnSeconds || s.TurnDurationSeconds > maxRoomTurnSeconds) {
+		return fmt.Errorf("turn duration %ds out of range [%d, %d]", s.TurnDurationSeconds, minRoomTurnSeconds, maxRoomTurnSeconds)
+	}
+	if len(s.Password) > maxRoomPasswordLength {
+		return fmt.Errorf("password longer than %d characters", maxRoomPasswordLength)
+	}
+	return nil
+}
+
+// matchParams returns the MatchCreate parameters for a room with the given code.
+func (s privateRoomSettings) matchParams(code string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":          int(pb.MatchType_MATCH_TYPE_CASUAL),
+		"private":       true,
+		"code":          code,
+		"password":      s.Password,
+		"tier":          s.Tier,
+		"rules":         s.Rules,
+		"turn_duration": s.TurnDurationSeconds,
+		"bots_enabled":  s.BotsEnabled,
+	}
+}
+
+// newRoomCode returns a random room code. The alphabet size divides 256, so every character is equally likely.
+func newRoomCode() (string, error) {
+	buf := make([]byte, roomCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = roomCodeAlphabet[int(b)%len(roomCodeAlphabet)]
+	}
+	return string(buf), nil
+}
+
+// normalizeRoomCode upper-cases a typed code and reports whether it can be a room code at all.
+func normalizeRoomCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != roomCodeLength {
+		return "", false
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(roomCodeAlphabet, c) {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+// readRoom reads the stored entry of a room code and its storage version. A code whose entry has no
+// match ID is reserved by a create that is still in progress, or that failed if the reservation is
+// older than roomReservationTTL.
+func readRoom(ctx context.Context, nk runtime.NakamaModule, code string) (privateRoomEntry, string, bool, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		{
+			Collection: privateRoomCollection,
+			Key:        code,
+		},
+	})
+	if err != nil || len(objects) == 0 {
+		return privateRoomEntry{}, "", false, err
+	}
+
+	var entry privateRoomEntry
+	if err := json.Unmarshal([]byte(objects[0].Value), &entry); err != nil {
+		return privateRoomEntry{}, "", false, err
+	}
+	return entry, objects[0].Version, true, nil
+}
+
+// lookupRoom resolves a room code to its live match. Codes of matches that have ended resolve to nothing
+// and may be reused.
+func lookupRoom(ctx context.Context, nk runtime.NakamaModule, code string) (privateRoomEntry, bool, error) {
+	entry, _, found, err := readRoom(ctx, nk, code)
+	if err != nil || !found || entry.MatchID == "" {
+		return privateRoomEntry{}, false, err
+	}
+	match, err := nk.MatchGet(ctx, entry.MatchID)
+	if err != nil || match == nil {
+		return privateRoomEntry{}, false, err
+	}
+	return entry, true, nil
+}
+
+// reserveRoomCode claims a free code with a conditional write, so two creates that draw the same code
+// cannot both take it. It returns the version of the reservation, or "" when the code is taken.
+func reserveRoomCode(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, code string) (string, error) {
+	entry, version, found, err := readRoom(ctx, nk, code)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().Unix()
+	if found {
+		if entry.MatchID == "" {
+			if now-entry.ReservedAt < roomReservationTTL {
+				return "", nil // Reserved by another create
+			}
+			logger.Warn("reserveRoomCode: Taking over code %s, left reserved by a failed create.", code)
+		} else if match, err := nk.MatchGet(ctx, entry.MatchID); err != nil || match != nil {
+			return "", err
+		}
+	} else {
+		version = "*" // Create only
+	}
+
+	value, _ := json.Marshal(privateRoomEntry{ReservedAt: now})
+	acks, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      privateRoomCollection,
+			Key:             code,
+			Value:           string(value),
+			Version:         version,
+			PermissionRead:  0, // No Read (Server only)
+			PermissionWrite: 0, // No Write (Server only)
+		},
+	})
+	if err != nil || len(acks) == 0 {
+		// Most likely another create took the code between the read and the write.
+		logger.Info("reserveRoomCode: Code %s not reserved: %v", code, err)
+		return "", nil
+	}
+	return acks[0].Version, nil
+}
+
+// releaseRoomCode deletes the code of a private match that is ending, unless the code already resolves
+// to another match.
+func releaseRoomCode(ctx context.Context, nk runtime.NakamaModule, logger runtime.Logger, code string) {
+	if code == "" || nk == nil {
+		return
+	}
+	matchId, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
+	entry, version, found, err := readRoom(ctx, nk, code)
+	if err != nil {
+		logger.Warn("releaseRoomCode: Failed to read code %s: %v", code, err)
+		return
+	}
+	if !found || entry.MatchID != matchId {
+		return
+	}
+	err = nk.StorageDelete(ctx, []*runtime.StorageDelete{
+		{
+			Collection: privateRoomCollection,
+			Key:        code,
+			Version:    version,
+		},
+	})
+	if err != nil {
+		logger.Warn("releaseRoomCode: Failed to delete code %s: %v", code, err)
+	}
+}
+
+// RpcCreatePrivateRoom creates a private match with the caller's settings. Private matches are never
+// returned by find_match; players join them through join_by_code.
+//
+// Payload: JSON {"tier": "casual", "rules": "northern", "turn_duration_seconds": 30, "bots_enabled": false, "password": "..."}; every field is optional.
+// Returns: JSON {"match_id": "...", "code": "K7M2QX"}
+func RpcCreatePrivateRoom(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+	userId, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+
+	var settings privateRoomSettings
+	if payload != "" {
+		if err := json.Unmarshal([]byte(payload), &settings); err != nil {
+			logger.Warn("RpcCreatePrivateRoom [User:%s]: Failed to unmarshal payload: %v", userId, err)
+			return "", newRpcError(pb.ErrorCode_ERROR_CODE_ROOM_SETTINGS_INVALID, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION, false, invalidArgumentCode)
+		}
+	}
+	if err := settings.validate(); err != nil {
+		logger.Warn("RpcCreatePrivateRoom [User:%s]: Invalid settings: %v", userId, err)
+		return "", newRpcError(pb.ErrorCode_ERROR_CODE_ROOM_SETTINGS_INVALID, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION, false, invalidArgumentCode)
+	}
+
+	// Draw codes until one can be reserved; a code whose match has ended is free again.
+	var code, version string
+	for attempt := 0; attempt < roomCodeAttempts && code == ""; attempt++ {
+		candidate, err := newRoomCode()
+		if err != nil {
+			return "", err
+		}
+		version, err = reserveRoomCode(ctx, nk, logger, candidate)
+		if err != nil {
+			logger.Error("RpcCreatePrivateRoom [User:%s]: Failed to look up code %s: %v", userId, candidate, err)
+			return "", err
+		}
+		if version != "" {
+			code = candidate
+		}
+	}
+	if code == "" {
+		return "", fmt.Errorf("no free room code after %d attempts", roomCodeAttempts)
+	}
+
+	matchId, err := nk.MatchCreate(ctx, MatchNameTienLen, settings.matchParams(code))
+	if err != nil {
+		logger.Error("RpcCreatePrivateRoom [User:%s]: Failed to create match: %v", userId, err)
+		_ = nk.StorageDelete(ctx, []*runtime.StorageDelete{{Collection: privateRoomCollection, Key: code, Version: version}})
+		return "", err
+	}
+
+	// Fill in the reservation; its version makes sure the code is still ours.
+	value, _ := json.Marshal(privateRoomEntry{MatchID: matchId, PasswordRequired: settings.Password != ""})
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{
+			Collection:      privateRoomCollection,
+			Key:             code,
+			Value:           string(value),
+			Version:         version,
+			PermissionRead:  0, // No Read (Server only)
+			PermissionWrite: 0, // No Write (Server only)
+		},
+	})
+	if err != nil {
+		// Nobody can reach the match without its code: free the code and close the empty match.
+		logger.Error("RpcCreatePrivateRoom [User:%s]: Failed to store code %s: %v", userId, code, err)
+		if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{Collection: privateRoomCollection, Key: code, Version: version}}); err != nil {
+			logger.Warn("RpcCreatePrivateRoom [User:%s]: Failed to free code %s: %v", userId, code, err)
+		}
+		if _, err := nk.MatchSignal(ctx, matchId, `{"op": "close"}`); err != nil {
+			logger.Warn("RpcCreatePrivateRoom [User:%s]: Failed to close match %s: %v", userId, matchId, err)
+		}
+		return "", err
+	}
+
+	logger.Info("RpcCreatePrivateRoom [User:%s]: Created private match %s with code %s", userId, matchId, code)
+	out, err := json.Marshal(map[string]string{"match_id": matchId, "code": code})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// RpcJoinByCode resolves a private room code to its match. Codes are case-insensitive.
+// The password, if any, is checked when joining the match (metadata "password").
+//
+// Payload: JSON {"code": "k7m2qx"}
+// Returns: JSON {"match_id": "...", "password_required": bool}
+func RpcJoinByCode(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+	userId, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(payload), &req); err != nil {
+		logger.Warn("RpcJoinByCode [User:%s]: Failed to unmarshal payload: %v", userId, err)
+		return "", newRpcError(pb.ErrorCode_ERROR_CODE_ROOM_NOT_FOUND, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION, false, invalidArgumentCode)
+	}
+
+	notFound := newRpcError(pb.ErrorCode_ERROR_CODE_ROOM_NOT_FOUND, pb.ErrorCategory_ERROR_CATEGORY_NOT_FOUND, false, notFoundCode)
+	code, ok := normalizeRoomCode(req.Code)
+	if !ok {
+		return "", notFound
+	}
+	entry, ok, err := lookupRoom(ctx, nk, code)
+	if err != nil {
+		logger.Error("RpcJoinByCode [User:%s]: Failed to look up code %s: %v", userId, code, err)
+		return "", err
+	}
+	if !ok {
+		logger.Info("RpcJoinByCode [User:%s]: No live room with code %s", userId, code)
+		return "", notFound
+	}
+
+	out, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
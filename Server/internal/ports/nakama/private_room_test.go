@@ -0,0 +1,317 @@
+package nakama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// fakeRoomNakama implements the storage and match calls used by the private room RPCs.
+// Any other NakamaModule call panics on the nil embedded interface.
+type fakeRoomNakama struct {
+	runtime.NakamaModule
+	storage      map[string]string // Key -> value in privateRoomCollection
+	versions     map[string]string // Key -> storage version
+	conflicts    int               // Create-only writes to reject as if another create got there first
+	failUpdates  bool              // Reject every write to an existing object
+	matches      map[string]map[string]interface{}
+	createdCount int
+	lastQuery    string
+	signals      []string // MatchSignal data, in order
+	gold         int64    // Wallet balance of every account
+}
+
+func newFakeRoomNakama() *fakeRoomNakama {
+	return &fakeRoomNakama{storage: make(map[string]string), versions: make(map[string]string), matches: make(map[string]map[string]interface{}), gold: 10000}
+}
+
+func (f *fakeRoomNakama) AccountGetId(ctx context.Context, userID string) (*api.Account, error) {
+	return &api.Account{Wallet: fmt.Sprintf(`{"gold": %d}`, f.gold)}, nil
+}
+
+func (f *fakeRoomNakama) StorageRead(ctx context.Context, reads []*runtime.StorageRead) ([]*api.StorageObject, error) {
+	var objects []*api.StorageObject
+	for _, r := range reads {
+		if value, ok := f.storage[r.Key]; ok && r.Collection == privateRoomCollection {
+			objects = append(objects, &api.StorageObject{Collection: r.Collection, Key: r.Key, Value: value, Version: f.versions[r.Key]})
+		}
+	}
+	return objects, nil
+}
+
+func (f *fakeRoomNakama) StorageWrite(ctx context.Context, writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, error) {
+	var acks []*api.StorageObjectAck
+	for _, w := range writes {
+		_, exists := f.storage[w.Key]
+		if exists && f.failUpdates {
+			return nil, errors.New("storage unavailable")
+		}
+		if w.Version == "*" && f.conflicts > 0 {
+			f.conflicts--
+			return nil, errors.New("storage write rejected - version check failed")
+		}
+		if (w.Version == "*" && exists) || (w.Version != "" && w.Version != "*" && w.Version != f.versions[w.Key]) {
+			return nil, errors.New("storage write rejected - version check failed")
+		}
+		f.storage[w.Key] = w.Value
+		f.versions[w.Key] = f.versions[w.Key] + "v"
+		acks = append(acks, &api.StorageObjectAck{Collection: w.Collection, Key: w.Key, Version: f.versions[w.Key]})
+	}
+	return acks, nil
+}
+
+func (f *fakeRoomNakama) StorageDelete(ctx context.Context, deletes []*runtime.StorageDelete) error {
+	for _, d := range deletes {
+		if d.Version != "" && d.Version != f.versions[d.Key] {
+			return errors.New("storage delete rejected - version check failed")
+		}
+		delete(f.storage, d.Key)
+		delete(f.versions, d.Key)
+	}
+	return nil
+}
+
+func (f *fakeRoomNakama) MatchCreate(ctx context.Context, module string, params map[string]interface{}) (string, error) {
+	f.createdCount++
+	id := "match-" + strings.Repeat("x", f.createdCount)
+	f.matches[id] = params
+	return id, nil
+}
+
+func (f *fakeRoomNakama) MatchGet(ctx context.Context, id string) (*api.Match, error) {
+	if _, ok := f.matches[id]; !ok {
+		return nil, nil
+	}
+	return &api.Match{MatchId: id}, nil
+}
+
+// MatchSignal records the signal; a "close" signal ends the match.
+func (f *fakeRoomNakama) MatchSignal(ctx context.Context, id string, data string) (string, error) {
+	f.signals = append(f.signals, data)
+	if strings.Contains(data, `"close"`) {
+		delete(f.matches, id)
+	}
+	return "", nil
+}
+
+func (f *fakeRoomNakama) MatchList(ctx context.Context, limit int, authoritative bool, label string, minSize, maxSize *int, query string) ([]*api.Match, error) {
+	f.lastQuery = query
+	return nil, nil
+}
+
+func TestNewRoomCode_UsesUnambiguousAlphabet(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code, err := newRoomCode()
+		if err != nil {
+			t.Fatalf("newRoomCode error: %v", err)
+		}
+		if normalized, ok := normalizeRoomCode(code); !ok || normalized != code {
+			t.Fatalf("generated code %q is not a valid room code", code)
+		}
+	}
+}
+
+func TestNormalizeRoomCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{" k7m2qx ", "K7M2QX", true},
+		{"K7M2Q", "", false},
+		{"K7M2Q0", "", false}, // 0 is not in the alphabet
+		{"", "", false},
+	}
+	for _, test := range tests {
+		got, ok := normalizeRoomCode(test.input)
+		if got != test.want || ok != test.ok {
+			t.Errorf("normalizeRoomCode(%q) = %q, %v; want %q, %v", test.input, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestPrivateRoomSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings privateRoomSettings
+		wantErr  bool
+	}{
+		{"defaults", privateRoomSettings{}, false},
+		{"turn in range", privateRoomSettings{TurnDurationSeconds: 30}, false},
+		{"turn too short", privateRoomSettings{TurnDurationSeconds: minRoomTurnSeconds - 1}, true},
+		{"turn too long", privateRoomSettings{TurnDurationSeconds: maxRoomTurnSeconds + 1}, true},
+		{"unknown tier", privateRoomSettings{Tier: "no_such_tier"}, true},
+		{"northern rules", privateRoomSettings{Rules: "northern"}, false},
+		{"unknown rules", privateRoomSettings{Rules: "western"}, true},
+		{"long password", privateRoomSettings{Password: strings.Repeat("p", maxRoomPasswordLength+1)}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.settings.validate(); (err != nil) != test.wantErr {
+				t.Fatalf("validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrivateRoom_CreateThenJoinByCode(t *testing.T) {
+	nk := newFakeRoomNakama()
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "owner")
+
+	raw, err := RpcCreatePrivateRoom(ctx, noopLogger{}, nil, nk, `{"turn_duration_seconds": 30, "bots_enabled": true, "password": "secret"}`)
+	if err != nil {
+		t.Fatalf("RpcCreatePrivateRoom error: %v", err)
+	}
+	var created struct {
+		MatchID string `json:"match_id"`
+		Code    string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(raw), &created); err != nil {
+		t.Fatalf("unmarshal create response: %v", err)
+	}
+
+	params := nk.matches[created.MatchID]
+	if params["private"] != true || params["code"] != created.Code || params["password"] != "secret" ||
+		params["turn_duration"] != 30 || params["bots_enabled"] != true {
+		t.Fatalf("unexpected match params %v", params)
+	}
+
+	raw, err = RpcJoinByCode(ctx, noopLogger{}, nil, nk, `{"code": "`+strings.ToLower(created.Code)+`"}`)
+	if err != nil {
+		t.Fatalf("RpcJoinByCode error: %v", err)
+	}
+	var joined privateRoomEntry
+	if err := json.Unmarshal([]byte(raw), &joined); err != nil {
+		t.Fatalf("unmarshal join response: %v", err)
+	}
+	if joined.MatchID != created.MatchID || !joined.PasswordRequired {
+		t.Fatalf("join_by_code = %+v, want match %s with password", joined, created.MatchID)
+	}
+
+	// Once the match is gone the code no longer resolves.
+	delete(nk.matches, created.MatchID)
+	if _, err := RpcJoinByCode(ctx, noopLogger{}, nil, nk, `{"code": "`+created.Code+`"}`); err == nil {
+		t.Fatal("expected an error for the code of an ended match")
+	}
+}
+
+func TestPrivateRoom_CodeConflictDrawsNewCode(t *testing.T) {
+	nk := newFakeRoomNakama()
+	nk.conflicts = 1 // Another create reserves the first code drawn
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "owner")
+
+	raw, err := RpcCreatePrivateRoom(ctx, noopLogger{}, nil, nk, "")
+	if err != nil {
+		t.Fatalf("RpcCreatePrivateRoom error: %v", err)
+	}
+	var created struct {
+		MatchID string `json:"match_id"`
+		Code    string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(raw), &created); err != nil {
+		t.Fatalf("unmarshal create response: %v", err)
+	}
+	if nk.createdCount != 1 || nk.matches[created.MatchID]["code"] != created.Code {
+		t.Fatalf("expected one match created with the reserved code, got %d matches", nk.createdCount)
+	}
+
+	// The code is freed when the match ends.
+	matchCtx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_MATCH_ID, created.MatchID)
+	(&matchHandler{}).MatchTerminate(matchCtx, noopLogger{}, nil, nk, nil, 0, &MatchState{RoomCode: created.Code}, 0)
+	if _, ok := nk.storage[created.Code]; ok {
+		t.Fatal("expected the code entry to be deleted when the match ended")
+	}
+}
+
+func TestPrivateRoom_FailedCodeWriteFreesCodeAndClosesMatch(t *testing.T) {
+	nk := newFakeRoomNakama()
+	nk.failUpdates = true // The reservation is created, filling it in fails
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "owner")
+
+	if _, err := RpcCreatePrivateRoom(ctx, noopLogger{}, nil, nk, ""); err == nil {
+		t.Fatal("expected the failed code write to be reported")
+	}
+	if len(nk.storage) != 0 {
+		t.Errorf("expected the reservation to be deleted, storage %v", nk.storage)
+	}
+	if nk.createdCount != 1 || len(nk.matches) != 0 || len(nk.signals) != 1 {
+		t.Errorf("expected the created match to be closed, matches %v, signals %v", nk.matches, nk.signals)
+	}
+}
+
+func TestReserveRoomCode_TakesOverStaleReservation(t *testing.T) {
+	nk := newFakeRoomNakama()
+	ctx := context.Background()
+
+	// A fresh reservation belongs to a create still in progress.
+	if version, err := reserveRoomCode(ctx, nk, noopLogger{}, "K7M2QX"); err != nil || version == "" {
+		t.Fatalf("reserveRoomCode = %q, %v; want the free code reserved", version, err)
+	}
+	if version, err := reserveRoomCode(ctx, nk, noopLogger{}, "K7M2QX"); err != nil || version != "" {
+		t.Fatalf("reserveRoomCode = %q, %v; want the reserved code taken", version, err)
+	}
+
+	// One left behind by a failed create, or by the empty reservations of older servers, is free.
+	for _, value := range []string{fmt.Sprintf(`{"reserved_at": %d}`, time.Now().Unix()-roomReservationTTL), "{}"} {
+		nk.storage["K7M2QX"] = value
+		if version, err := reserveRoomCode(ctx, nk, noopLogger{}, "K7M2QX"); err != nil || version == "" {
+			t.Errorf("reserveRoomCode over %s = %q, %v; want the stale reservation taken over", value, version, err)
+		}
+	}
+}
+
+func TestMatchSignal_CloseOnlyEndsEmptyMatch(t *testing.T) {
+	handler := &matchHandler{}
+	state := newTestMatchState("user-1")
+	if next, _ := handler.MatchSignal(context.Background(), noopLogger{}, nil, nil, nil, 0, state, `{"op": "close"}`); next == nil {
+		t.Fatal("a match with players must not be closed")
+	}
+	state = newTestMatchState()
+	if next, result := handler.MatchSignal(context.Background(), noopLogger{}, nil, nil, nil, 0, state, `{"op": "close"}`); next != nil {
+		t.Fatalf("expected the empty match to end, got %q", result)
+	}
+}
+
+func TestRpcJoinByCode_InvalidPayload(t *testing.T) {
+	nk := newFakeRoomNakama()
+	_, err := RpcJoinByCode(context.Background(), noopLogger{}, nil, nk, "not json")
+	if err == nil || !strings.Contains(err.Error(), `"app_code":1002`) {
+		t.Fatalf("RpcJoinByCode error = %v, want a structured ERROR_CODE_ROOM_NOT_FOUND", err)
+	}
+}
+
+func TestFindMatch_ExcludesPrivateRooms(t *testing.T) {
+	nk := newFakeRoomNakama()
+	ctx := context.WithValue(context.Background(), runtime.RUNTIME_CTX_USER_ID, "user-1")
+
+	if _, err := RpcFindMatch(ctx, noopLogger{}, nil, nk, ""); err != nil {
+		t.Fatalf("RpcFindMatch error: %v", err)
+	}
+	if !strings.Contains(nk.lastQuery, "-label."+MatchLabelKey_Private+":T") {
+		t.Fatalf("find_match query %q does not exclude private rooms", nk.lastQuery)
+	}
+	for _, params := range nk.matches {
+		if params["private"] == true {
+			t.Fatalf("find_match created a private match: %v", params)
+		}
+	}
+}
+
+func TestMatchJoinAttempt_PrivateRoomPassword(t *testing.T) {
+	handler := &matchHandler{}
+	state := &MatchState{Password: "secret", SpectatorsAllowed: true}
+
+	if _, ok, _ := handler.MatchJoinAttempt(context.Background(), noopLogger{}, nil, nil, nil, 0, state, testPresence{"user-1"}, map[string]string{"password": "wrong"}); ok {
+		t.Fatal("expected a wrong password to be rejected")
+	}
+	if _, ok, reason := handler.MatchJoinAttempt(context.Background(), noopLogger{}, nil, nil, nil, 0, state, testPresence{"user-1"}, map[string]string{"password": "secret"}); !ok {
+		t.Fatalf("expected the right password to be accepted, got %q", reason)
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
-	"unicode"
 
 	"tienlen/internal/app"
+	"tienlen/internal/config"
 	"tienlen/internal/domain"
 	pb "tienlen/proto"
 
@@ -21,14 +22,21 @@ var vivoxService *app.VivoxService
 // If an available match is found, it returns the Match ID.
 // If no match is found, it creates a new match and returns its ID.
 //
-// Payload: JSON containing "type" (int32)
+// Only matches of the requested bet tier and rule set are considered; an empty tier means the default tier
+// and empty rules mean Southern. With "quick_play" the tier is the highest one the caller's balance admits instead.
+//
+// Payload: JSON containing "type" (int32), "tier" (string, a configured BetTier ID), "rules" (string, a rule
+// set name such as "northern") and "quick_play" (bool)
 // Returns: String containing the Match ID.
 func RpcFindMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
 	userId, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
 	const permissionDeniedCode = 7
 
 	type findMatchReq struct {
-		Type int32 `json:"type"`
+		Type      int32  `json:"type"`
+		Tier      string `json:"tier"`
+		Rules     string `json:"rules"`
+		QuickPlay bool   `json:"quick_play"`
 	}
 	var req findMatchReq
 	if payload != "" {
@@ -42,6 +50,40 @@ func RpcFindMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk run
 	if matchType == pb.MatchType_MATCH_TYPE_UNSPECIFIED {
 		matchType = pb.MatchType_MATCH_TYPE_CASUAL
 	}
+	if req.Tier != "" && !config.HasTier(req.Tier) {
+		logger.Warn("RpcFindMatch [User:%s]: Unknown tier %q", userId, req.Tier)
+		return "", newRpcError(pb.ErrorCode_ERROR_CODE_TIER_INVALID, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION, false, invalidArgumentCode)
+	}
+	tier := config.ResolveTierID(req.Tier)
+	rules, err := domain.RuleSetByName(req.Rules, domain.RuleOptions{})
+	if err != nil {
+		logger.Warn("RpcFindMatch [User:%s]: Unknown rule set %q", userId, req.Rules)
+		return "", newRpcError(pb.ErrorCode_ERROR_CODE_RULES_INVALID, pb.ErrorCategory_ERROR_CATEGORY_VALIDATION, false, invalidArgumentCode)
+	}
+
+	// Players only sit where their balance is within the tier's limits (see BetTier.MinBalance).
+	economy := NewNakamaEconomyAdapter(nk)
+	if req.QuickPlay {
+		balance, err := economy.GetBalance(ctx, userId)
+		if err != nil {
+			logger.Error("RpcFindMatch [User:%s]: Failed to get balance: %v", userId, err)
+			return "", err
+		}
+		best, ok := config.HighestAffordableTier(balance)
+		if !ok {
+			return "", newRpcError(pb.ErrorCode_ERROR_CODE_BALANCE_TOO_LOW, pb.ErrorCategory_ERROR_CATEGORY_ACCESS, false, failedPreconditionCode)
+		}
+		tier = best.ID
+	} else if betTier, ok := config.GetBetTier(tier); ok {
+		code, err := tierBalanceError(ctx, economy, betTier, userId)
+		if err != nil {
+			logger.Error("RpcFindMatch [User:%s]: Failed to check balance: %v", userId, err)
+			return "", err
+		}
+		if code != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			return "", newRpcError(code, pb.ErrorCategory_ERROR_CATEGORY_ACCESS, false, failedPreconditionCode)
+		}
+	}
 
 	// 1. VIP Check
 	if matchType == pb.MatchType_MATCH_TYPE_VIP {
@@ -63,29 +105,18 @@ func RpcFindMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk run
 		}
 
 		if !isVip {
-			type errorPayload struct {
-				AppCode   int32 `json:"app_code"`
-				Category  int32 `json:"category"`
-				Retryable bool  `json:"retryable"`
-			}
-
-			payloadBytes, err := json.Marshal(errorPayload{
-				AppCode:   int32(pb.ErrorCode_ERROR_CODE_MATCH_VIP_REQUIRED),
-				Category:  int32(pb.ErrorCategory_ERROR_CATEGORY_ACCESS),
-				Retryable: false,
-			})
-			if err != nil {
-				payloadBytes = []byte("{}")
-			}
-
-			return "", runtime.NewError(string(payloadBytes), permissionDeniedCode)
+			return "", newRpcError(pb.ErrorCode_ERROR_CODE_MATCH_VIP_REQUIRED, pb.ErrorCategory_ERROR_CATEGORY_ACCESS, false, permissionDeniedCode)
 		}
 	}
 
-	// 2. Search for matches with at least 1 open seat and matching type.
+	// 2. Search for public matches with at least 1 open seat and matching type, tier and rules.
+	// Booleans are indexed as T/F in the label index.
 	limit := 1
 	authoritative := true
-	labelQuery := fmt.Sprintf("+label.%s:>=1 +label.%s:%d", MatchLabelKey_OpenSeats, MatchLabelKey_Type, int32(matchType))
+	labelQuery := fmt.Sprintf("+label.%s:>=1 +label.%s:%d -label.%s:T +label.%s:%s", MatchLabelKey_OpenSeats, MatchLabelKey_Type, int32(matchType), MatchLabelKey_Private, MatchLabelKey_Rules, rules.Name())
+	if tier != "" {
+		labelQuery += fmt.Sprintf(" +label.%s:%s", MatchLabelKey_Tier, tier)
+	}
 	minSize := 0
 	maxSize := 4
 
@@ -98,14 +129,16 @@ func RpcFindMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk run
 	// 3. If a match is found, return its ID.
 	if len(matches) > 0 {
 		matchId := matches[0].MatchId
-		logger.Info("RpcFindMatch [User:%s]: Found existing match %s of type %d", userId, matchId, matchType)
+		logger.Info("RpcFindMatch [User:%s]: Found existing match %s of type %d, tier %q", userId, matchId, matchType, tier)
 		return fmt.Sprintf("%q", matchId), nil
 	}
 
 	// 4. If no match is found, create a new one.
 	moduleName := MatchNameTienLen // Must match the name registered in InitModule
 	params := map[string]interface{}{
-		"type": int(matchType),
+		"type":  int(matchType),
+		"tier":  tier,
+		"rules": rules.Name(),
 	}
 	matchId, err := nk.MatchCreate(ctx, moduleName, params)
 	if err != nil {
@@ -113,10 +146,80 @@ func RpcFindMatch(ctx context.Context, logger runtime.Logger, db *sql.DB, nk run
 		return "", err
 	}
 
-	logger.Info("RpcFindMatch [User:%s]: Created new match %s of type %d", userId, matchId, matchType)
+	logger.Info("RpcFindMatch [User:%s]: Created new match %s of type %d, tier %q", userId, matchId, matchType, tier)
 	return fmt.Sprintf("%q", matchId), nil
 }
 
+// newRpcError builds a runtime error whose message is the structured JSON payload clients parse
+// (see errorPayload).
+func newRpcError(code pb.ErrorCode, category pb.ErrorCategory, retryable bool, grpcCode int) error {
+	return runtime.NewError(errorPayload(code, category, retryable), grpcCode)
+}
+
+// errorPayload returns the structured error JSON clients parse from RPC errors and join rejections:
+// {"app_code": <pb.ErrorCode>, "category": <pb.ErrorCategory>, "retryable": bool}.
+func errorPayload(code pb.ErrorCode, category pb.ErrorCategory, retryable bool) string {
+	type payload struct {
+		AppCode   int32 `json:"app_code"`
+		Category  int32 `json:"category"`
+		Retryable bool  `json:"retryable"`
+	}
+
+	payloadBytes, err := json.Marshal(payload{
+		AppCode:   int32(code),
+		Category:  int32(category),
+		Retryable: retryable,
+	})
+	if err != nil {
+		return "{}"
+	}
+	return string(payloadBytes)
+}
+
+// RpcVerifyDeal recomputes a provably fair deal from the values revealed in GameEndedEvent.
+//
+// Payload: JSON {"server_seed": "<hex>", "client_salt": "...", "seed_commitment": "<hex>"}
+// Returns: JSON {"valid": bool, "deck": "3S 4H ...", "deals": ["...", ...]}; deals are the 13-card
+// blocks dealt to the occupied seats in seat order. valid is false when the seed does not match the commitment.
+func RpcVerifyDeal(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+	type verifyDealReq struct {
+		ServerSeed     string `json:"server_seed"`
+		ClientSalt     string `json:"client_salt"`
+		SeedCommitment string `json:"seed_commitment"`
+	}
+	type verifyDealResp struct {
+		Valid bool     `json:"valid"`
+		Deck  string   `json:"deck,omitempty"`
+		Deals []string `json:"deals,omitempty"`
+	}
+
+	var req verifyDealReq
+	if err := json.Unmarshal([]byte(payload), &req); err != nil {
+		return "", fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	var resp verifyDealResp
+	deck, err := domain.VerifyDeal(req.ServerSeed, req.ClientSalt, req.SeedCommitment)
+	switch {
+	case errors.Is(err, domain.ErrCommitmentMismatch):
+		logger.Warn("RpcVerifyDeal: Seed does not match commitment %s", req.SeedCommitment)
+	case err != nil:
+		return "", err
+	default:
+		resp.Valid = true
+		resp.Deck = domain.FormatCards(deck, domain.NotationText)
+		for i := 0; i+13 <= len(deck); i += 13 {
+			resp.Deals = append(resp.Deals, domain.FormatCards(deck[i:i+13], domain.NotationText))
+		}
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
 // RpcSetVip is for testing/dev to grant VIP status.
 func RpcSetVip(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
 	userId, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
@@ -263,7 +366,7 @@ func parseRiggedHandTexts(handsText []riggedHandText) ([]riggedHandPlan, error)
 
 		for _, card := range cards {
 			if seenCards[card] {
-				return nil, fmt.Errorf("duplicate card detected: %v", card)
+				return nil, fmt.Errorf("duplicate card detected: %s", card)
 			}
 			seenCards[card] = true
 		}
@@ -278,25 +381,21 @@ func parseRiggedHandTexts(handsText []riggedHandText) ([]riggedHandPlan, error)
 	return hands, nil
 }
 
-// parseCardList parses a comma/space delimited list of cards, honoring ALL tokens.
+// parseCardList parses a comma/space delimited list of cards (see domain.ParseCards), honoring ALL tokens.
 func parseCardList(input string) ([]domain.Card, bool, error) {
-	tokens := splitCardTokens(input)
+	tokens := domain.SplitCardTokens(input)
 	cards := make([]domain.Card, 0, len(tokens))
 	sawAll := false
 
 	for _, token := range tokens {
-		if token == "" {
-			continue
-		}
-		normalized := strings.ToUpper(strings.TrimSpace(token))
-		if normalized == "ALL" {
+		if strings.EqualFold(token, "ALL") {
 			sawAll = true
 			continue
 		}
 
-		card, err := parseCardToken(normalized)
+		card, err := domain.ParseCard(token)
 		if err != nil {
-			return nil, false, fmt.Errorf("invalid card token %q", token)
+			return nil, false, err
 		}
 		cards = append(cards, card)
 	}
@@ -308,84 +407,6 @@ func parseCardList(input string) ([]domain.Card, bool, error) {
 	return cards, sawAll, nil
 }
 
-// splitCardTokens splits a card list string on commas, semicolons, or whitespace.
-func splitCardTokens(input string) []string {
-	return strings.FieldsFunc(input, func(r rune) bool {
-		return r == ',' || r == ';' || unicode.IsSpace(r)
-	})
-}
-
-// parseCardToken parses a single card token like 3H or 10S.
-func parseCardToken(token string) (domain.Card, error) {
-	if len(token) < 2 {
-		return domain.Card{}, fmt.Errorf("token too short")
-	}
-
-	rankText := token[:len(token)-1]
-	suitText := token[len(token)-1:]
-
-	rank, ok := parseCardRank(rankText)
-	if !ok {
-		return domain.Card{}, fmt.Errorf("invalid rank %q", rankText)
-	}
-	suit, ok := parseCardSuit(suitText)
-	if !ok {
-		return domain.Card{}, fmt.Errorf("invalid suit %q", suitText)
-	}
-
-	return domain.Card{Rank: rank, Suit: suit}, nil
-}
-
-// parseCardRank maps rank tokens to domain rank values.
-func parseCardRank(rankText string) (int32, bool) {
-	switch rankText {
-	case "3":
-		return 0, true
-	case "4":
-		return 1, true
-	case "5":
-		return 2, true
-	case "6":
-		return 3, true
-	case "7":
-		return 4, true
-	case "8":
-		return 5, true
-	case "9":
-		return 6, true
-	case "10":
-		return 7, true
-	case "J":
-		return 8, true
-	case "Q":
-		return 9, true
-	case "K":
-		return 10, true
-	case "A":
-		return 11, true
-	case "2":
-		return 12, true
-	default:
-		return 0, false
-	}
-}
-
-// parseCardSuit maps suit tokens to domain suit values.
-func parseCardSuit(suitText string) (int32, bool) {
-	switch suitText {
-	case "S":
-		return 0, true
-	case "C":
-		return 1, true
-	case "D":
-		return 2, true
-	case "H":
-		return 3, true
-	default:
-		return 0, false
-	}
-}
-
 // buildRiggedDeck builds a full 52-card deck honoring rigged plans.
 func buildRiggedDeck(plans []riggedHandPlan, defaultFillAll bool) ([]domain.Card, error) {
 	return buildRiggedDeckWithShuffler(plans, defaultFillAll, domain.ShuffleDeck)
@@ -409,7 +430,7 @@ func buildRiggedDeckWithShuffler(plans []riggedHandPlan, defaultFillAll bool, sh
 
 		for _, card := range plan.Cards {
 			if usedCards[card] {
-				return nil, fmt.Errorf("duplicate card detected: %v", card)
+				return nil, fmt.Errorf("duplicate card detected: %s", card)
 			}
 			usedCards[card] = true
 		}
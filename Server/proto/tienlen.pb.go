@@ -261,6 +261,9 @@ const (
 	OpCode_OP_CODE_PLAY_CARDS       OpCode = 2
 	OpCode_OP_CODE_PASS_TURN        OpCode = 3
 	OpCode_OP_CODE_REQUEST_NEW_GAME OpCode = 4
+	OpCode_OP_CODE_REQUEST_HINT     OpCode = 5
+	OpCode_OP_CODE_SET_SPECTATING   OpCode = 6
+	OpCode_OP_CODE_SET_READY        OpCode = 7
 	OpCode_OP_CODE_PLAYER_JOINED    OpCode = 50
 	OpCode_OP_CODE_PLAYER_LEFT      OpCode = 51
 	OpCode_OP_CODE_GAME_STARTED     OpCode = 100
@@ -271,6 +274,10 @@ const (
 	OpCode_OP_CODE_PIG_CHOPPED      OpCode = 106
 	OpCode_OP_CODE_PLAYER_FINISHED  OpCode = 107
 	OpCode_OP_CODE_IN_GAME_CHAT     OpCode = 108
+	OpCode_OP_CODE_INSTANT_WIN      OpCode = 109
+	OpCode_OP_CODE_HINT             OpCode = 110
+	OpCode_OP_CODE_GAME_RESYNC      OpCode = 111
+	OpCode_OP_CODE_REMATCH_VOTE     OpCode = 112
 )
 
 // Enum value maps for OpCode.
@@ -281,6 +288,9 @@ var (
 		2:   "OP_CODE_PLAY_CARDS",
 		3:   "OP_CODE_PASS_TURN",
 		4:   "OP_CODE_REQUEST_NEW_GAME",
+		5:   "OP_CODE_REQUEST_HINT",
+		6:   "OP_CODE_SET_SPECTATING",
+		7:   "OP_CODE_SET_READY",
 		50:  "OP_CODE_PLAYER_JOINED",
 		51:  "OP_CODE_PLAYER_LEFT",
 		100: "OP_CODE_GAME_STARTED",
@@ -291,6 +301,10 @@ var (
 		106: "OP_CODE_PIG_CHOPPED",
 		107: "OP_CODE_PLAYER_FINISHED",
 		108: "OP_CODE_IN_GAME_CHAT",
+		109: "OP_CODE_INSTANT_WIN",
+		110: "OP_CODE_HINT",
+		111: "OP_CODE_GAME_RESYNC",
+		112: "OP_CODE_REMATCH_VOTE",
 	}
 	OpCode_value = map[string]int32{
 		"OP_CODE_UNSPECIFIED":      0,
@@ -298,6 +312,9 @@ var (
 		"OP_CODE_PLAY_CARDS":       2,
 		"OP_CODE_PASS_TURN":        3,
 		"OP_CODE_REQUEST_NEW_GAME": 4,
+		"OP_CODE_REQUEST_HINT":     5,
+		"OP_CODE_SET_SPECTATING":   6,
+		"OP_CODE_SET_READY":        7,
 		"OP_CODE_PLAYER_JOINED":    50,
 		"OP_CODE_PLAYER_LEFT":      51,
 		"OP_CODE_GAME_STARTED":     100,
@@ -308,6 +325,10 @@ var (
 		"OP_CODE_PIG_CHOPPED":      106,
 		"OP_CODE_PLAYER_FINISHED":  107,
 		"OP_CODE_IN_GAME_CHAT":     108,
+		"OP_CODE_INSTANT_WIN":      109,
+		"OP_CODE_HINT":             110,
+		"OP_CODE_GAME_RESYNC":      111,
+		"OP_CODE_REMATCH_VOTE":     112,
 	}
 )
 
@@ -405,8 +426,14 @@ func (ErrorCategory) EnumDescriptor() ([]byte, []int) {
 type ErrorCode int32
 
 const (
-	ErrorCode_ERROR_CODE_UNSPECIFIED        ErrorCode = 0
-	ErrorCode_ERROR_CODE_MATCH_VIP_REQUIRED ErrorCode = 1001
+	ErrorCode_ERROR_CODE_UNSPECIFIED           ErrorCode = 0
+	ErrorCode_ERROR_CODE_MATCH_VIP_REQUIRED    ErrorCode = 1001
+	ErrorCode_ERROR_CODE_ROOM_NOT_FOUND        ErrorCode = 1002
+	ErrorCode_ERROR_CODE_ROOM_SETTINGS_INVALID ErrorCode = 1003
+	ErrorCode_ERROR_CODE_TIER_INVALID          ErrorCode = 1004
+	ErrorCode_ERROR_CODE_BALANCE_TOO_LOW       ErrorCode = 1005 // Below the tier's minimum balance
+	ErrorCode_ERROR_CODE_BALANCE_TOO_HIGH      ErrorCode = 1006 // Above the tier's maximum balance
+	ErrorCode_ERROR_CODE_RULES_INVALID         ErrorCode = 1007 // Unknown rule set name
 )
 
 // Enum value maps for ErrorCode.
@@ -414,10 +441,22 @@ var (
 	ErrorCode_name = map[int32]string{
 		0:    "ERROR_CODE_UNSPECIFIED",
 		1001: "ERROR_CODE_MATCH_VIP_REQUIRED",
+		1002: "ERROR_CODE_ROOM_NOT_FOUND",
+		1003: "ERROR_CODE_ROOM_SETTINGS_INVALID",
+		1004: "ERROR_CODE_TIER_INVALID",
+		1005: "ERROR_CODE_BALANCE_TOO_LOW",
+		1006: "ERROR_CODE_BALANCE_TOO_HIGH",
+		1007: "ERROR_CODE_RULES_INVALID",
 	}
 	ErrorCode_value = map[string]int32{
-		"ERROR_CODE_UNSPECIFIED":        0,
-		"ERROR_CODE_MATCH_VIP_REQUIRED": 1001,
+		"ERROR_CODE_UNSPECIFIED":           0,
+		"ERROR_CODE_MATCH_VIP_REQUIRED":    1001,
+		"ERROR_CODE_ROOM_NOT_FOUND":        1002,
+		"ERROR_CODE_ROOM_SETTINGS_INVALID": 1003,
+		"ERROR_CODE_TIER_INVALID":          1004,
+		"ERROR_CODE_BALANCE_TOO_LOW":       1005,
+		"ERROR_CODE_BALANCE_TOO_HIGH":      1006,
+		"ERROR_CODE_RULES_INVALID":         1007,
 	}
 )
 
@@ -453,6 +492,10 @@ type MatchLabel struct {
 	Open          int32                  `protobuf:"varint,1,opt,name=open,proto3" json:"open,omitempty"`
 	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
 	Type          int32                  `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	Spectators    int32                  `protobuf:"varint,4,opt,name=spectators,proto3" json:"spectators,omitempty"`
+	Private       bool                   `protobuf:"varint,5,opt,name=private,proto3" json:"private,omitempty"` // Private rooms are joined by code, never matchmade
+	Tier          string                 `protobuf:"bytes,6,opt,name=tier,proto3" json:"tier,omitempty"`        // Bet tier ID, e.g. "casual"
+	Rules         string                 `protobuf:"bytes,7,opt,name=rules,proto3" json:"rules,omitempty"`      // Rule set name, e.g. "southern"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -508,6 +551,34 @@ func (x *MatchLabel) GetType() int32 {
 	return 0
 }
 
+func (x *MatchLabel) GetSpectators() int32 {
+	if x != nil {
+		return x.Spectators
+	}
+	return 0
+}
+
+func (x *MatchLabel) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *MatchLabel) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *MatchLabel) GetRules() string {
+	if x != nil {
+		return x.Rules
+	}
+	return ""
+}
+
 type Card struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Suit          Suit                   `protobuf:"varint,1,opt,name=suit,proto3,enum=tienlen.v1.Suit" json:"suit,omitempty"`
@@ -570,6 +641,8 @@ type PlayerState struct {
 	AvatarIndex    int32                  `protobuf:"varint,6,opt,name=avatar_index,json=avatarIndex,proto3" json:"avatar_index,omitempty"`
 	Balance        int64                  `protobuf:"varint,7,opt,name=balance,proto3" json:"balance,omitempty"` // Public balance (bots always report 0).
 	IsVip          bool                   `protobuf:"varint,8,opt,name=is_vip,json=isVip,proto3" json:"is_vip,omitempty"`
+	Autopilot      bool                   `protobuf:"varint,9,opt,name=autopilot,proto3" json:"autopilot,omitempty"` // Disconnected; a bot plays this seat until the player rejoins
+	Ready          bool                   `protobuf:"varint,10,opt,name=ready,proto3" json:"ready,omitempty"`        // Ready to start the next game (bots are always ready)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -660,6 +733,20 @@ func (x *PlayerState) GetIsVip() bool {
 	return false
 }
 
+func (x *PlayerState) GetAutopilot() bool {
+	if x != nil {
+		return x.Autopilot
+	}
+	return false
+}
+
+func (x *PlayerState) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
 type FindMatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -698,6 +785,7 @@ func (*FindMatchRequest) Descriptor() ([]byte, []int) {
 
 type StartGameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientSalt    string                 `protobuf:"bytes,1,opt,name=client_salt,json=clientSalt,proto3" json:"client_salt,omitempty"` // Optional; mixed into the provably fair shuffle
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -732,6 +820,13 @@ func (*StartGameRequest) Descriptor() ([]byte, []int) {
 	return file_tienlen_proto_rawDescGZIP(), []int{4}
 }
 
+func (x *StartGameRequest) GetClientSalt() string {
+	if x != nil {
+		return x.ClientSalt
+	}
+	return ""
+}
+
 type FindMatchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
@@ -856,8 +951,10 @@ func (*PassTurnRequest) Descriptor() ([]byte, []int) {
 	return file_tienlen_proto_rawDescGZIP(), []int{7}
 }
 
+// A yes vote for a rematch while the vote after GameEndedEvent is open.
 type RequestNewGameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientSalt    string                 `protobuf:"bytes,1,opt,name=client_salt,json=clientSalt,proto3" json:"client_salt,omitempty"` // Optional; with the other voters' salts, mixed into the shuffle of the rematch
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -892,6 +989,146 @@ func (*RequestNewGameRequest) Descriptor() ([]byte, []int) {
 	return file_tienlen_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *RequestNewGameRequest) GetClientSalt() string {
+	if x != nil {
+		return x.ClientSalt
+	}
+	return ""
+}
+
+type RequestHintRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestHintRequest) Reset() {
+	*x = RequestHintRequest{}
+	mi := &file_tienlen_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestHintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestHintRequest) ProtoMessage() {}
+
+func (x *RequestHintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestHintRequest.ProtoReflect.Descriptor instead.
+func (*RequestHintRequest) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{9}
+}
+
+// Owner only. Disabling spectating removes current spectators.
+type SetSpectatingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSpectatingRequest) Reset() {
+	*x = SetSpectatingRequest{}
+	mi := &file_tienlen_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSpectatingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSpectatingRequest) ProtoMessage() {}
+
+func (x *SetSpectatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSpectatingRequest.ProtoReflect.Descriptor instead.
+func (*SetSpectatingRequest) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetSpectatingRequest) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+type SetReadyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ready         bool                   `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	ClientSalt    string                 `protobuf:"bytes,2,opt,name=client_salt,json=clientSalt,proto3" json:"client_salt,omitempty"` // Optional; with the other ready players' salts, mixed into the shuffle of a countdown start
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReadyRequest) Reset() {
+	*x = SetReadyRequest{}
+	mi := &file_tienlen_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReadyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReadyRequest) ProtoMessage() {}
+
+func (x *SetReadyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReadyRequest.ProtoReflect.Descriptor instead.
+func (*SetReadyRequest) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetReadyRequest) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *SetReadyRequest) GetClientSalt() string {
+	if x != nil {
+		return x.ClientSalt
+	}
+	return ""
+}
+
 type InGameChatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -901,7 +1138,7 @@ type InGameChatRequest struct {
 
 func (x *InGameChatRequest) Reset() {
 	*x = InGameChatRequest{}
-	mi := &file_tienlen_proto_msgTypes[9]
+	mi := &file_tienlen_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -913,7 +1150,7 @@ func (x *InGameChatRequest) String() string {
 func (*InGameChatRequest) ProtoMessage() {}
 
 func (x *InGameChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[9]
+	mi := &file_tienlen_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,7 +1163,7 @@ func (x *InGameChatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InGameChatRequest.ProtoReflect.Descriptor instead.
 func (*InGameChatRequest) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{9}
+	return file_tienlen_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *InGameChatRequest) GetMessage() string {
@@ -945,7 +1182,7 @@ type PlayerJoinedEvent struct {
 
 func (x *PlayerJoinedEvent) Reset() {
 	*x = PlayerJoinedEvent{}
-	mi := &file_tienlen_proto_msgTypes[10]
+	mi := &file_tienlen_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -957,7 +1194,7 @@ func (x *PlayerJoinedEvent) String() string {
 func (*PlayerJoinedEvent) ProtoMessage() {}
 
 func (x *PlayerJoinedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[10]
+	mi := &file_tienlen_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -970,7 +1207,7 @@ func (x *PlayerJoinedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerJoinedEvent.ProtoReflect.Descriptor instead.
 func (*PlayerJoinedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{10}
+	return file_tienlen_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PlayerJoinedEvent) GetPlayer() *PlayerState {
@@ -990,7 +1227,7 @@ type PlayerLeftEvent struct {
 
 func (x *PlayerLeftEvent) Reset() {
 	*x = PlayerLeftEvent{}
-	mi := &file_tienlen_proto_msgTypes[11]
+	mi := &file_tienlen_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1002,7 +1239,7 @@ func (x *PlayerLeftEvent) String() string {
 func (*PlayerLeftEvent) ProtoMessage() {}
 
 func (x *PlayerLeftEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[11]
+	mi := &file_tienlen_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1252,7 @@ func (x *PlayerLeftEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerLeftEvent.ProtoReflect.Descriptor instead.
 func (*PlayerLeftEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{11}
+	return file_tienlen_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PlayerLeftEvent) GetSeat() int32 {
@@ -1034,20 +1271,28 @@ func (x *PlayerLeftEvent) GetUserId() string {
 
 // Snapshot of lobby state broadcast after a player joins.
 type MatchStateSnapshot struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	Seats                []string               `protobuf:"bytes,1,rep,name=seats,proto3" json:"seats,omitempty"`
-	OwnerSeat            int32                  `protobuf:"varint,2,opt,name=owner_seat,json=ownerSeat,proto3" json:"owner_seat,omitempty"` // 0-based index
-	Tick                 int64                  `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
-	Players              []*PlayerState         `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`                                                          // Full player details
-	TurnSecondsRemaining int64                  `protobuf:"varint,5,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"` // Seconds remaining before the current turn expires
-	Type                 int32                  `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Seats                 []string               `protobuf:"bytes,1,rep,name=seats,proto3" json:"seats,omitempty"`
+	OwnerSeat             int32                  `protobuf:"varint,2,opt,name=owner_seat,json=ownerSeat,proto3" json:"owner_seat,omitempty"` // 0-based index
+	Tick                  int64                  `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Players               []*PlayerState         `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`                                                          // Full player details
+	TurnSecondsRemaining  int64                  `protobuf:"varint,5,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"` // Seconds remaining before the current turn expires
+	Type                  int32                  `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	DealCommitment        string                 `protobuf:"bytes,7,opt,name=deal_commitment,json=dealCommitment,proto3" json:"deal_commitment,omitempty"` // SHA-256 (hex) of the next game's server seed, published before the deal
+	SpectatorCount        int32                  `protobuf:"varint,8,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	SpectatorsAllowed     bool                   `protobuf:"varint,9,opt,name=spectators_allowed,json=spectatorsAllowed,proto3" json:"spectators_allowed,omitempty"`
+	RoomCode              string                 `protobuf:"bytes,10,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`                                           // Code to share a private room; empty for public matches
+	StartCountdownSeconds int64                  `protobuf:"varint,11,opt,name=start_countdown_seconds,json=startCountdownSeconds,proto3" json:"start_countdown_seconds,omitempty"` // Seconds until the game starts automatically; 0 when no countdown is running
+	Tier                  string                 `protobuf:"bytes,12,opt,name=tier,proto3" json:"tier,omitempty"`                                                                   // Bet tier ID of the table
+	BaseBet               int64                  `protobuf:"varint,13,opt,name=base_bet,json=baseBet,proto3" json:"base_bet,omitempty"`                                             // Stake of the tier, for display
+	Rules                 string                 `protobuf:"bytes,14,opt,name=rules,proto3" json:"rules,omitempty"`                                                                 // Rule set name of the table, e.g. "northern"
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *MatchStateSnapshot) Reset() {
 	*x = MatchStateSnapshot{}
-	mi := &file_tienlen_proto_msgTypes[12]
+	mi := &file_tienlen_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1059,7 +1304,7 @@ func (x *MatchStateSnapshot) String() string {
 func (*MatchStateSnapshot) ProtoMessage() {}
 
 func (x *MatchStateSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[12]
+	mi := &file_tienlen_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,7 +1317,7 @@ func (x *MatchStateSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchStateSnapshot.ProtoReflect.Descriptor instead.
 func (*MatchStateSnapshot) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{12}
+	return file_tienlen_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *MatchStateSnapshot) GetSeats() []string {
@@ -1117,19 +1362,76 @@ func (x *MatchStateSnapshot) GetType() int32 {
 	return 0
 }
 
+func (x *MatchStateSnapshot) GetDealCommitment() string {
+	if x != nil {
+		return x.DealCommitment
+	}
+	return ""
+}
+
+func (x *MatchStateSnapshot) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+func (x *MatchStateSnapshot) GetSpectatorsAllowed() bool {
+	if x != nil {
+		return x.SpectatorsAllowed
+	}
+	return false
+}
+
+func (x *MatchStateSnapshot) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *MatchStateSnapshot) GetStartCountdownSeconds() int64 {
+	if x != nil {
+		return x.StartCountdownSeconds
+	}
+	return 0
+}
+
+func (x *MatchStateSnapshot) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *MatchStateSnapshot) GetBaseBet() int64 {
+	if x != nil {
+		return x.BaseBet
+	}
+	return 0
+}
+
+func (x *MatchStateSnapshot) GetRules() string {
+	if x != nil {
+		return x.Rules
+	}
+	return ""
+}
+
 type GameStartedEvent struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	FirstTurnSeat        int32                  `protobuf:"varint,1,opt,name=first_turn_seat,json=firstTurnSeat,proto3" json:"first_turn_seat,omitempty"` // 0-based index
 	Phase                GamePhase              `protobuf:"varint,2,opt,name=phase,proto3,enum=tienlen.v1.GamePhase" json:"phase,omitempty"`
 	Hand                 []*Card                `protobuf:"bytes,3,rep,name=hand,proto3" json:"hand,omitempty"`
 	TurnSecondsRemaining int64                  `protobuf:"varint,4,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"` // Seconds remaining before the current turn expires
+	SeedCommitment       string                 `protobuf:"bytes,5,opt,name=seed_commitment,json=seedCommitment,proto3" json:"seed_commitment,omitempty"`                      // SHA-256 (hex) of the server seed; revealed in GameEndedEvent
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
 
 func (x *GameStartedEvent) Reset() {
 	*x = GameStartedEvent{}
-	mi := &file_tienlen_proto_msgTypes[13]
+	mi := &file_tienlen_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1141,7 +1443,7 @@ func (x *GameStartedEvent) String() string {
 func (*GameStartedEvent) ProtoMessage() {}
 
 func (x *GameStartedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[13]
+	mi := &file_tienlen_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1154,7 +1456,7 @@ func (x *GameStartedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameStartedEvent.ProtoReflect.Descriptor instead.
 func (*GameStartedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{13}
+	return file_tienlen_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GameStartedEvent) GetFirstTurnSeat() int32 {
@@ -1185,32 +1487,44 @@ func (x *GameStartedEvent) GetTurnSecondsRemaining() int64 {
 	return 0
 }
 
-type CardPlayedEvent struct {
+func (x *GameStartedEvent) GetSeedCommitment() string {
+	if x != nil {
+		return x.SeedCommitment
+	}
+	return ""
+}
+
+// Sent privately to a player who rejoins a game in progress so the client can rebuild the table.
+// Spectators get one without a hand when they join a running game and when a game starts.
+type GameResyncEvent struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
-	Seat                 int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"` // 0-based index
-	Cards                []*Card                `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
-	NextTurnSeat         int32                  `protobuf:"varint,3,opt,name=next_turn_seat,json=nextTurnSeat,proto3" json:"next_turn_seat,omitempty"`                         // 0-based index
-	NewRound             bool                   `protobuf:"varint,4,opt,name=new_round,json=newRound,proto3" json:"new_round,omitempty"`                                       // True if this clears the board
-	TurnSecondsRemaining int64                  `protobuf:"varint,5,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"` // Seconds remaining before the next turn expires
+	Hand                 []*Card                `protobuf:"bytes,1,rep,name=hand,proto3" json:"hand,omitempty"`
+	LastPlayedCards      []*Card                `protobuf:"bytes,2,rep,name=last_played_cards,json=lastPlayedCards,proto3" json:"last_played_cards,omitempty"`                     // Combination on the table; empty when the round is fresh
+	LastPlayerToPlaySeat int32                  `protobuf:"varint,3,opt,name=last_player_to_play_seat,json=lastPlayerToPlaySeat,proto3" json:"last_player_to_play_seat,omitempty"` // 0-based index; -1 before the first play
+	CurrentTurnSeat      int32                  `protobuf:"varint,4,opt,name=current_turn_seat,json=currentTurnSeat,proto3" json:"current_turn_seat,omitempty"`                    // 0-based index
+	PassedSeats          []int32                `protobuf:"varint,5,rep,packed,name=passed_seats,json=passedSeats,proto3" json:"passed_seats,omitempty"`                           // Seats that passed this round
+	FinishOrderSeats     []int32                `protobuf:"varint,6,rep,packed,name=finish_order_seats,json=finishOrderSeats,proto3" json:"finish_order_seats,omitempty"`          // Seats that have finished, in order
+	TurnSecondsRemaining int64                  `protobuf:"varint,7,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"`     // Seconds remaining before the current turn expires
+	Discards             []*Card                `protobuf:"bytes,8,rep,name=discards,proto3" json:"discards,omitempty"`                                                            // Every card played this game, in play order
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
 
-func (x *CardPlayedEvent) Reset() {
-	*x = CardPlayedEvent{}
-	mi := &file_tienlen_proto_msgTypes[14]
+func (x *GameResyncEvent) Reset() {
+	*x = GameResyncEvent{}
+	mi := &file_tienlen_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CardPlayedEvent) String() string {
+func (x *GameResyncEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CardPlayedEvent) ProtoMessage() {}
+func (*GameResyncEvent) ProtoMessage() {}
 
-func (x *CardPlayedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[14]
+func (x *GameResyncEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1221,49 +1535,207 @@ func (x *CardPlayedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CardPlayedEvent.ProtoReflect.Descriptor instead.
-func (*CardPlayedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GameResyncEvent.ProtoReflect.Descriptor instead.
+func (*GameResyncEvent) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CardPlayedEvent) GetSeat() int32 {
+func (x *GameResyncEvent) GetHand() []*Card {
 	if x != nil {
-		return x.Seat
+		return x.Hand
 	}
-	return 0
+	return nil
 }
 
-func (x *CardPlayedEvent) GetCards() []*Card {
+func (x *GameResyncEvent) GetLastPlayedCards() []*Card {
 	if x != nil {
-		return x.Cards
+		return x.LastPlayedCards
 	}
 	return nil
 }
 
-func (x *CardPlayedEvent) GetNextTurnSeat() int32 {
+func (x *GameResyncEvent) GetLastPlayerToPlaySeat() int32 {
 	if x != nil {
-		return x.NextTurnSeat
+		return x.LastPlayerToPlaySeat
 	}
 	return 0
 }
 
-func (x *CardPlayedEvent) GetNewRound() bool {
+func (x *GameResyncEvent) GetCurrentTurnSeat() int32 {
 	if x != nil {
-		return x.NewRound
+		return x.CurrentTurnSeat
 	}
-	return false
+	return 0
 }
 
-func (x *CardPlayedEvent) GetTurnSecondsRemaining() int64 {
+func (x *GameResyncEvent) GetPassedSeats() []int32 {
 	if x != nil {
-		return x.TurnSecondsRemaining
+		return x.PassedSeats
 	}
-	return 0
+	return nil
 }
 
-type TurnPassedEvent struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	Seat                 int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`                                                               // 0-based index
+func (x *GameResyncEvent) GetFinishOrderSeats() []int32 {
+	if x != nil {
+		return x.FinishOrderSeats
+	}
+	return nil
+}
+
+func (x *GameResyncEvent) GetTurnSecondsRemaining() int64 {
+	if x != nil {
+		return x.TurnSecondsRemaining
+	}
+	return 0
+}
+
+func (x *GameResyncEvent) GetDiscards() []*Card {
+	if x != nil {
+		return x.Discards
+	}
+	return nil
+}
+
+// Broadcast when the rematch vote opens after GameEndedEvent and on every vote.
+type RematchVoteEvent struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VotedSeats       []int32                `protobuf:"varint,1,rep,packed,name=voted_seats,json=votedSeats,proto3" json:"voted_seats,omitempty"`            // Seats that want a rematch; bots always do
+	WaitingSeats     []int32                `protobuf:"varint,2,rep,packed,name=waiting_seats,json=waitingSeats,proto3" json:"waiting_seats,omitempty"`      // Seats that have not voted yet
+	SecondsRemaining int64                  `protobuf:"varint,3,opt,name=seconds_remaining,json=secondsRemaining,proto3" json:"seconds_remaining,omitempty"` // Seconds before non-voters lose their seat
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RematchVoteEvent) Reset() {
+	*x = RematchVoteEvent{}
+	mi := &file_tienlen_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchVoteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchVoteEvent) ProtoMessage() {}
+
+func (x *RematchVoteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchVoteEvent.ProtoReflect.Descriptor instead.
+func (*RematchVoteEvent) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RematchVoteEvent) GetVotedSeats() []int32 {
+	if x != nil {
+		return x.VotedSeats
+	}
+	return nil
+}
+
+func (x *RematchVoteEvent) GetWaitingSeats() []int32 {
+	if x != nil {
+		return x.WaitingSeats
+	}
+	return nil
+}
+
+func (x *RematchVoteEvent) GetSecondsRemaining() int64 {
+	if x != nil {
+		return x.SecondsRemaining
+	}
+	return 0
+}
+
+type CardPlayedEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Seat                 int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"` // 0-based index
+	Cards                []*Card                `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	NextTurnSeat         int32                  `protobuf:"varint,3,opt,name=next_turn_seat,json=nextTurnSeat,proto3" json:"next_turn_seat,omitempty"`                         // 0-based index
+	NewRound             bool                   `protobuf:"varint,4,opt,name=new_round,json=newRound,proto3" json:"new_round,omitempty"`                                       // True if this clears the board
+	TurnSecondsRemaining int64                  `protobuf:"varint,5,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"` // Seconds remaining before the next turn expires
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CardPlayedEvent) Reset() {
+	*x = CardPlayedEvent{}
+	mi := &file_tienlen_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CardPlayedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CardPlayedEvent) ProtoMessage() {}
+
+func (x *CardPlayedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CardPlayedEvent.ProtoReflect.Descriptor instead.
+func (*CardPlayedEvent) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CardPlayedEvent) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *CardPlayedEvent) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *CardPlayedEvent) GetNextTurnSeat() int32 {
+	if x != nil {
+		return x.NextTurnSeat
+	}
+	return 0
+}
+
+func (x *CardPlayedEvent) GetNewRound() bool {
+	if x != nil {
+		return x.NewRound
+	}
+	return false
+}
+
+func (x *CardPlayedEvent) GetTurnSecondsRemaining() int64 {
+	if x != nil {
+		return x.TurnSecondsRemaining
+	}
+	return 0
+}
+
+type TurnPassedEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Seat                 int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`                                                               // 0-based index
 	NextTurnSeat         int32                  `protobuf:"varint,2,opt,name=next_turn_seat,json=nextTurnSeat,proto3" json:"next_turn_seat,omitempty"`                         // 0-based index
 	NewRound             bool                   `protobuf:"varint,3,opt,name=new_round,json=newRound,proto3" json:"new_round,omitempty"`                                       // True if this pass results in a round reset
 	TurnSecondsRemaining int64                  `protobuf:"varint,4,opt,name=turn_seconds_remaining,json=turnSecondsRemaining,proto3" json:"turn_seconds_remaining,omitempty"` // Seconds remaining before the next turn expires
@@ -1273,7 +1745,7 @@ type TurnPassedEvent struct {
 
 func (x *TurnPassedEvent) Reset() {
 	*x = TurnPassedEvent{}
-	mi := &file_tienlen_proto_msgTypes[15]
+	mi := &file_tienlen_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1285,7 +1757,7 @@ func (x *TurnPassedEvent) String() string {
 func (*TurnPassedEvent) ProtoMessage() {}
 
 func (x *TurnPassedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[15]
+	mi := &file_tienlen_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +1770,7 @@ func (x *TurnPassedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TurnPassedEvent.ProtoReflect.Descriptor instead.
 func (*TurnPassedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{15}
+	return file_tienlen_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *TurnPassedEvent) GetSeat() int32 {
@@ -1338,7 +1810,7 @@ type CardList struct {
 
 func (x *CardList) Reset() {
 	*x = CardList{}
-	mi := &file_tienlen_proto_msgTypes[16]
+	mi := &file_tienlen_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1350,7 +1822,7 @@ func (x *CardList) String() string {
 func (*CardList) ProtoMessage() {}
 
 func (x *CardList) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[16]
+	mi := &file_tienlen_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1363,7 +1835,7 @@ func (x *CardList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CardList.ProtoReflect.Descriptor instead.
 func (*CardList) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{16}
+	return file_tienlen_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CardList) GetCards() []*Card {
@@ -1378,13 +1850,18 @@ type GameEndedEvent struct {
 	FinishOrderSeats []int32                `protobuf:"varint,1,rep,packed,name=finish_order_seats,json=finishOrderSeats,proto3" json:"finish_order_seats,omitempty"`                                                            // 0-based seat indices in rank order
 	BalanceChanges   map[string]int64       `protobuf:"bytes,2,rep,name=balance_changes,json=balanceChanges,proto3" json:"balance_changes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // UserID -> Gold (+/-)
 	RemainingHands   map[int32]*CardList    `protobuf:"bytes,3,rep,name=remaining_hands,json=remainingHands,proto3" json:"remaining_hands,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Seat Index -> Cards
+	Penalties        []*SettlementPenalty   `protobuf:"bytes,4,rep,name=penalties,proto3" json:"penalties,omitempty"`                                                                                                            // Line items already included in balance_changes
+	FrozenSeats      []int32                `protobuf:"varint,5,rep,packed,name=frozen_seats,json=frozenSeats,proto3" json:"frozen_seats,omitempty"`                                                                             // Seats flagged "cong" (never played a card)
+	SeedCommitment   string                 `protobuf:"bytes,6,opt,name=seed_commitment,json=seedCommitment,proto3" json:"seed_commitment,omitempty"`                                                                            // Commitment published in GameStartedEvent
+	ServerSeed       string                 `protobuf:"bytes,7,opt,name=server_seed,json=serverSeed,proto3" json:"server_seed,omitempty"`                                                                                        // Revealed server seed (hex); verify with the verify_deal RPC
+	ClientSalt       string                 `protobuf:"bytes,8,opt,name=client_salt,json=clientSalt,proto3" json:"client_salt,omitempty"`                                                                                        // Client salt mixed into the shuffle
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GameEndedEvent) Reset() {
 	*x = GameEndedEvent{}
-	mi := &file_tienlen_proto_msgTypes[17]
+	mi := &file_tienlen_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1396,7 +1873,7 @@ func (x *GameEndedEvent) String() string {
 func (*GameEndedEvent) ProtoMessage() {}
 
 func (x *GameEndedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[17]
+	mi := &file_tienlen_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1409,7 +1886,7 @@ func (x *GameEndedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameEndedEvent.ProtoReflect.Descriptor instead.
 func (*GameEndedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{17}
+	return file_tienlen_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GameEndedEvent) GetFinishOrderSeats() []int32 {
@@ -1433,17 +1910,130 @@ func (x *GameEndedEvent) GetRemainingHands() map[int32]*CardList {
 	return nil
 }
 
+func (x *GameEndedEvent) GetPenalties() []*SettlementPenalty {
+	if x != nil {
+		return x.Penalties
+	}
+	return nil
+}
+
+func (x *GameEndedEvent) GetFrozenSeats() []int32 {
+	if x != nil {
+		return x.FrozenSeats
+	}
+	return nil
+}
+
+func (x *GameEndedEvent) GetSeedCommitment() string {
+	if x != nil {
+		return x.SeedCommitment
+	}
+	return ""
+}
+
+func (x *GameEndedEvent) GetServerSeed() string {
+	if x != nil {
+		return x.ServerSeed
+	}
+	return ""
+}
+
+func (x *GameEndedEvent) GetClientSalt() string {
+	if x != nil {
+		return x.ClientSalt
+	}
+	return ""
+}
+
+// A single charge applied at game end, e.g. a 2 left in a loser's hand ("thoi heo").
+type SettlementPenalty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayerSeat     int32                  `protobuf:"varint,1,opt,name=payer_seat,json=payerSeat,proto3" json:"payer_seat,omitempty"` // 0-based index
+	PayeeSeat     int32                  `protobuf:"varint,2,opt,name=payee_seat,json=payeeSeat,proto3" json:"payee_seat,omitempty"` // 0-based index
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                         // "Black Pig", "Red Pig", "Quad", "3-Pine", "4-Pine", "5-Pine", "Cong"
+	Cards         []*Card                `protobuf:"bytes,4,rep,name=cards,proto3" json:"cards,omitempty"`
+	Amount        int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettlementPenalty) Reset() {
+	*x = SettlementPenalty{}
+	mi := &file_tienlen_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettlementPenalty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettlementPenalty) ProtoMessage() {}
+
+func (x *SettlementPenalty) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettlementPenalty.ProtoReflect.Descriptor instead.
+func (*SettlementPenalty) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SettlementPenalty) GetPayerSeat() int32 {
+	if x != nil {
+		return x.PayerSeat
+	}
+	return 0
+}
+
+func (x *SettlementPenalty) GetPayeeSeat() int32 {
+	if x != nil {
+		return x.PayeeSeat
+	}
+	return 0
+}
+
+func (x *SettlementPenalty) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SettlementPenalty) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *SettlementPenalty) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 type PlayerFinishedEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"` // 0-based index
-	Rank          int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"` // 1 = 1st, 2 = 2nd, etc.
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`     // 0-based index
+	Rank          int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`     // 1 = 1st, 2 = 2nd, etc.
+	Frozen        bool                   `protobuf:"varint,3,opt,name=frozen,proto3" json:"frozen,omitempty"` // "cong": never played a card, ranked last
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlayerFinishedEvent) Reset() {
 	*x = PlayerFinishedEvent{}
-	mi := &file_tienlen_proto_msgTypes[18]
+	mi := &file_tienlen_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1455,7 +2045,7 @@ func (x *PlayerFinishedEvent) String() string {
 func (*PlayerFinishedEvent) ProtoMessage() {}
 
 func (x *PlayerFinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[18]
+	mi := &file_tienlen_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1468,7 +2058,7 @@ func (x *PlayerFinishedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerFinishedEvent.ProtoReflect.Descriptor instead.
 func (*PlayerFinishedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{18}
+	return file_tienlen_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PlayerFinishedEvent) GetSeat() int32 {
@@ -1485,17 +2075,25 @@ func (x *PlayerFinishedEvent) GetRank() int32 {
 	return 0
 }
 
+func (x *PlayerFinishedEvent) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
+}
+
 type GameErrorEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AppCode       ErrorCode              `protobuf:"varint,3,opt,name=app_code,json=appCode,proto3,enum=tienlen.v1.ErrorCode" json:"app_code,omitempty"` // Set for errors clients handle specifically
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GameErrorEvent) Reset() {
 	*x = GameErrorEvent{}
-	mi := &file_tienlen_proto_msgTypes[19]
+	mi := &file_tienlen_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1507,7 +2105,7 @@ func (x *GameErrorEvent) String() string {
 func (*GameErrorEvent) ProtoMessage() {}
 
 func (x *GameErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[19]
+	mi := &file_tienlen_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1520,7 +2118,7 @@ func (x *GameErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameErrorEvent.ProtoReflect.Descriptor instead.
 func (*GameErrorEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{19}
+	return file_tienlen_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GameErrorEvent) GetCode() int32 {
@@ -1537,6 +2135,13 @@ func (x *GameErrorEvent) GetMessage() string {
 	return ""
 }
 
+func (x *GameErrorEvent) GetAppCode() ErrorCode {
+	if x != nil {
+		return x.AppCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
 type PigChoppedEvent struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	SourceSeat     int32                  `protobuf:"varint,1,opt,name=source_seat,json=sourceSeat,proto3" json:"source_seat,omitempty"` // 0-based index
@@ -1545,13 +2150,14 @@ type PigChoppedEvent struct {
 	CardsChopped   []*Card                `protobuf:"bytes,4,rep,name=cards_chopped,json=cardsChopped,proto3" json:"cards_chopped,omitempty"`
 	CardsChopping  []*Card                `protobuf:"bytes,5,rep,name=cards_chopping,json=cardsChopping,proto3" json:"cards_chopping,omitempty"`
 	BalanceChanges map[string]int64       `protobuf:"bytes,6,rep,name=balance_changes,json=balanceChanges,proto3" json:"balance_changes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // UserID -> Gold (+/-)
+	Chain          []*ChopLink            `protobuf:"bytes,7,rep,name=chain,proto3" json:"chain,omitempty"`                                                                                                                    // Stacked chops this round ("chat chong"), original victim first
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PigChoppedEvent) Reset() {
 	*x = PigChoppedEvent{}
-	mi := &file_tienlen_proto_msgTypes[20]
+	mi := &file_tienlen_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1563,7 +2169,7 @@ func (x *PigChoppedEvent) String() string {
 func (*PigChoppedEvent) ProtoMessage() {}
 
 func (x *PigChoppedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[20]
+	mi := &file_tienlen_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1576,7 +2182,7 @@ func (x *PigChoppedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PigChoppedEvent.ProtoReflect.Descriptor instead.
 func (*PigChoppedEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{20}
+	return file_tienlen_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *PigChoppedEvent) GetSourceSeat() int32 {
@@ -1621,6 +2227,142 @@ func (x *PigChoppedEvent) GetBalanceChanges() map[string]int64 {
 	return nil
 }
 
+func (x *PigChoppedEvent) GetChain() []*ChopLink {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+type ChopLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"` // 0-based index
+	Cards         []*Card                `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	ChopType      string                 `protobuf:"bytes,3,opt,name=chop_type,json=chopType,proto3" json:"chop_type,omitempty"` // Empty for the original victim
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                    // Cumulative amount paid by the previous link's owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChopLink) Reset() {
+	*x = ChopLink{}
+	mi := &file_tienlen_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChopLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChopLink) ProtoMessage() {}
+
+func (x *ChopLink) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChopLink.ProtoReflect.Descriptor instead.
+func (*ChopLink) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ChopLink) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *ChopLink) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *ChopLink) GetChopType() string {
+	if x != nil {
+		return x.ChopType
+	}
+	return ""
+}
+
+func (x *ChopLink) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Sent right after dealing when a hand wins instantly ("toi trang"); a GameEndedEvent follows.
+type InstantWinEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`                     // 0-based index
+	WinType       string                 `protobuf:"bytes,2,opt,name=win_type,json=winType,proto3" json:"win_type,omitempty"` // "Dragon Straight", "Four Twos", "Five Consecutive Pairs", "Six Pairs"
+	Cards         []*Card                `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`                    // The winning hand
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstantWinEvent) Reset() {
+	*x = InstantWinEvent{}
+	mi := &file_tienlen_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstantWinEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstantWinEvent) ProtoMessage() {}
+
+func (x *InstantWinEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstantWinEvent.ProtoReflect.Descriptor instead.
+func (*InstantWinEvent) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *InstantWinEvent) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *InstantWinEvent) GetWinType() string {
+	if x != nil {
+		return x.WinType
+	}
+	return ""
+}
+
+func (x *InstantWinEvent) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
 type InGameChatEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SeatIndex     int32                  `protobuf:"varint,1,opt,name=seat_index,json=seatIndex,proto3" json:"seat_index,omitempty"` // 0-based index
@@ -1631,7 +2373,7 @@ type InGameChatEvent struct {
 
 func (x *InGameChatEvent) Reset() {
 	*x = InGameChatEvent{}
-	mi := &file_tienlen_proto_msgTypes[21]
+	mi := &file_tienlen_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1643,7 +2385,7 @@ func (x *InGameChatEvent) String() string {
 func (*InGameChatEvent) ProtoMessage() {}
 
 func (x *InGameChatEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_tienlen_proto_msgTypes[21]
+	mi := &file_tienlen_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1656,7 +2398,7 @@ func (x *InGameChatEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InGameChatEvent.ProtoReflect.Descriptor instead.
 func (*InGameChatEvent) Descriptor() ([]byte, []int) {
-	return file_tienlen_proto_rawDescGZIP(), []int{21}
+	return file_tienlen_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *InGameChatEvent) GetSeatIndex() int32 {
@@ -1673,20 +2415,147 @@ func (x *InGameChatEvent) GetMessage() string {
 	return ""
 }
 
+// Private reply to RequestHintRequest, best suggestion first.
+type HintEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions     []*HintSuggestion      `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	HintsRemaining  int32                  `protobuf:"varint,2,opt,name=hints_remaining,json=hintsRemaining,proto3" json:"hints_remaining,omitempty"`    // -1 when unlimited
+	CooldownSeconds int64                  `protobuf:"varint,3,opt,name=cooldown_seconds,json=cooldownSeconds,proto3" json:"cooldown_seconds,omitempty"` // Seconds before the next hint may be requested
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HintEvent) Reset() {
+	*x = HintEvent{}
+	mi := &file_tienlen_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintEvent) ProtoMessage() {}
+
+func (x *HintEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintEvent.ProtoReflect.Descriptor instead.
+func (*HintEvent) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *HintEvent) GetSuggestions() []*HintSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *HintEvent) GetHintsRemaining() int32 {
+	if x != nil {
+		return x.HintsRemaining
+	}
+	return 0
+}
+
+func (x *HintEvent) GetCooldownSeconds() int64 {
+	if x != nil {
+		return x.CooldownSeconds
+	}
+	return 0
+}
+
+type HintSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pass          bool                   `protobuf:"varint,1,opt,name=pass,proto3" json:"pass,omitempty"` // True when passing is the suggestion; cards is then empty
+	Cards         []*Card                `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	Reasons       []string               `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"` // Short explanations, e.g. "chops the table"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HintSuggestion) Reset() {
+	*x = HintSuggestion{}
+	mi := &file_tienlen_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintSuggestion) ProtoMessage() {}
+
+func (x *HintSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_tienlen_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintSuggestion.ProtoReflect.Descriptor instead.
+func (*HintSuggestion) Descriptor() ([]byte, []int) {
+	return file_tienlen_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *HintSuggestion) GetPass() bool {
+	if x != nil {
+		return x.Pass
+	}
+	return false
+}
+
+func (x *HintSuggestion) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *HintSuggestion) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
 var File_tienlen_proto protoreflect.FileDescriptor
 
 const file_tienlen_proto_rawDesc = "" +
 	"\n" +
 	"\rtienlen.proto\x12\n" +
-	"tienlen.v1\"J\n" +
+	"tienlen.v1\"\xae\x01\n" +
 	"\n" +
 	"MatchLabel\x12\x12\n" +
 	"\x04open\x18\x01 \x01(\x05R\x04open\x12\x14\n" +
 	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\x05R\x04type\"R\n" +
+	"\x04type\x18\x03 \x01(\x05R\x04type\x12\x1e\n" +
+	"\n" +
+	"spectators\x18\x04 \x01(\x05R\n" +
+	"spectators\x12\x18\n" +
+	"\aprivate\x18\x05 \x01(\bR\aprivate\x12\x12\n" +
+	"\x04tier\x18\x06 \x01(\tR\x04tier\x12\x14\n" +
+	"\x05rules\x18\a \x01(\tR\x05rules\"R\n" +
 	"\x04Card\x12$\n" +
 	"\x04suit\x18\x01 \x01(\x0e2\x10.tienlen.v1.SuitR\x04suit\x12$\n" +
-	"\x04rank\x18\x02 \x01(\x0e2\x10.tienlen.v1.RankR\x04rank\"\xf5\x01\n" +
+	"\x04rank\x18\x02 \x01(\x0e2\x10.tienlen.v1.RankR\x04rank\"\xa9\x02\n" +
 	"\vPlayerState\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04seat\x18\x02 \x01(\x05R\x04seat\x12\x19\n" +
@@ -1695,22 +2564,36 @@ const file_tienlen_proto_rawDesc = "" +
 	"\fdisplay_name\x18\x05 \x01(\tR\vdisplayName\x12!\n" +
 	"\favatar_index\x18\x06 \x01(\x05R\vavatarIndex\x12\x18\n" +
 	"\abalance\x18\a \x01(\x03R\abalance\x12\x15\n" +
-	"\x06is_vip\x18\b \x01(\bR\x05isVip\"\x12\n" +
-	"\x10FindMatchRequest\"\x12\n" +
-	"\x10StartGameRequest\".\n" +
+	"\x06is_vip\x18\b \x01(\bR\x05isVip\x12\x1c\n" +
+	"\tautopilot\x18\t \x01(\bR\tautopilot\x12\x14\n" +
+	"\x05ready\x18\n" +
+	" \x01(\bR\x05ready\"\x12\n" +
+	"\x10FindMatchRequest\"3\n" +
+	"\x10StartGameRequest\x12\x1f\n" +
+	"\vclient_salt\x18\x01 \x01(\tR\n" +
+	"clientSalt\".\n" +
 	"\x11FindMatchResponse\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\":\n" +
 	"\x10PlayCardsRequest\x12&\n" +
 	"\x05cards\x18\x01 \x03(\v2\x10.tienlen.v1.CardR\x05cards\"\x11\n" +
-	"\x0fPassTurnRequest\"\x17\n" +
-	"\x15RequestNewGameRequest\"-\n" +
+	"\x0fPassTurnRequest\"8\n" +
+	"\x15RequestNewGameRequest\x12\x1f\n" +
+	"\vclient_salt\x18\x01 \x01(\tR\n" +
+	"clientSalt\"\x14\n" +
+	"\x12RequestHintRequest\"0\n" +
+	"\x14SetSpectatingRequest\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\"H\n" +
+	"\x0fSetReadyRequest\x12\x14\n" +
+	"\x05ready\x18\x01 \x01(\bR\x05ready\x12\x1f\n" +
+	"\vclient_salt\x18\x02 \x01(\tR\n" +
+	"clientSalt\"-\n" +
 	"\x11InGameChatRequest\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"D\n" +
 	"\x11PlayerJoinedEvent\x12/\n" +
 	"\x06player\x18\x01 \x01(\v2\x17.tienlen.v1.PlayerStateR\x06player\">\n" +
 	"\x0fPlayerLeftEvent\x12\x12\n" +
 	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xda\x01\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xf5\x03\n" +
 	"\x12MatchStateSnapshot\x12\x14\n" +
 	"\x05seats\x18\x01 \x03(\tR\x05seats\x12\x1d\n" +
 	"\n" +
@@ -1718,12 +2601,36 @@ const file_tienlen_proto_rawDesc = "" +
 	"\x04tick\x18\x03 \x01(\x03R\x04tick\x121\n" +
 	"\aplayers\x18\x04 \x03(\v2\x17.tienlen.v1.PlayerStateR\aplayers\x124\n" +
 	"\x16turn_seconds_remaining\x18\x05 \x01(\x03R\x14turnSecondsRemaining\x12\x12\n" +
-	"\x04type\x18\x06 \x01(\x05R\x04type\"\xc3\x01\n" +
+	"\x04type\x18\x06 \x01(\x05R\x04type\x12'\n" +
+	"\x0fdeal_commitment\x18\a \x01(\tR\x0edealCommitment\x12'\n" +
+	"\x0fspectator_count\x18\b \x01(\x05R\x0espectatorCount\x12-\n" +
+	"\x12spectators_allowed\x18\t \x01(\bR\x11spectatorsAllowed\x12\x1b\n" +
+	"\troom_code\x18\n" +
+	" \x01(\tR\broomCode\x126\n" +
+	"\x17start_countdown_seconds\x18\v \x01(\x03R\x15startCountdownSeconds\x12\x12\n" +
+	"\x04tier\x18\f \x01(\tR\x04tier\x12\x19\n" +
+	"\bbase_bet\x18\r \x01(\x03R\abaseBet\x12\x14\n" +
+	"\x05rules\x18\x0e \x01(\tR\x05rules\"\xec\x01\n" +
 	"\x10GameStartedEvent\x12&\n" +
 	"\x0ffirst_turn_seat\x18\x01 \x01(\x05R\rfirstTurnSeat\x12+\n" +
 	"\x05phase\x18\x02 \x01(\x0e2\x15.tienlen.v1.GamePhaseR\x05phase\x12$\n" +
 	"\x04hand\x18\x03 \x03(\v2\x10.tienlen.v1.CardR\x04hand\x124\n" +
-	"\x16turn_seconds_remaining\x18\x04 \x01(\x03R\x14turnSecondsRemaining\"\xc6\x01\n" +
+	"\x16turn_seconds_remaining\x18\x04 \x01(\x03R\x14turnSecondsRemaining\x12'\n" +
+	"\x0fseed_commitment\x18\x05 \x01(\tR\x0eseedCommitment\"\x8e\x03\n" +
+	"\x0fGameResyncEvent\x12$\n" +
+	"\x04hand\x18\x01 \x03(\v2\x10.tienlen.v1.CardR\x04hand\x12<\n" +
+	"\x11last_played_cards\x18\x02 \x03(\v2\x10.tienlen.v1.CardR\x0flastPlayedCards\x126\n" +
+	"\x18last_player_to_play_seat\x18\x03 \x01(\x05R\x14lastPlayerToPlaySeat\x12*\n" +
+	"\x11current_turn_seat\x18\x04 \x01(\x05R\x0fcurrentTurnSeat\x12!\n" +
+	"\fpassed_seats\x18\x05 \x03(\x05R\vpassedSeats\x12,\n" +
+	"\x12finish_order_seats\x18\x06 \x03(\x05R\x10finishOrderSeats\x124\n" +
+	"\x16turn_seconds_remaining\x18\a \x01(\x03R\x14turnSecondsRemaining\x12,\n" +
+	"\bdiscards\x18\b \x03(\v2\x10.tienlen.v1.CardR\bdiscards\"\x85\x01\n" +
+	"\x10RematchVoteEvent\x12\x1f\n" +
+	"\vvoted_seats\x18\x01 \x03(\x05R\n" +
+	"votedSeats\x12#\n" +
+	"\rwaiting_seats\x18\x02 \x03(\x05R\fwaitingSeats\x12+\n" +
+	"\x11seconds_remaining\x18\x03 \x01(\x03R\x10secondsRemaining\"\xc6\x01\n" +
 	"\x0fCardPlayedEvent\x12\x12\n" +
 	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12&\n" +
 	"\x05cards\x18\x02 \x03(\v2\x10.tienlen.v1.CardR\x05cards\x12$\n" +
@@ -1736,23 +2643,40 @@ const file_tienlen_proto_rawDesc = "" +
 	"\tnew_round\x18\x03 \x01(\bR\bnewRound\x124\n" +
 	"\x16turn_seconds_remaining\x18\x04 \x01(\x03R\x14turnSecondsRemaining\"2\n" +
 	"\bCardList\x12&\n" +
-	"\x05cards\x18\x01 \x03(\v2\x10.tienlen.v1.CardR\x05cards\"\x8c\x03\n" +
+	"\x05cards\x18\x01 \x03(\v2\x10.tienlen.v1.CardR\x05cards\"\xd7\x04\n" +
 	"\x0eGameEndedEvent\x12,\n" +
 	"\x12finish_order_seats\x18\x01 \x03(\x05R\x10finishOrderSeats\x12W\n" +
 	"\x0fbalance_changes\x18\x02 \x03(\v2..tienlen.v1.GameEndedEvent.BalanceChangesEntryR\x0ebalanceChanges\x12W\n" +
-	"\x0fremaining_hands\x18\x03 \x03(\v2..tienlen.v1.GameEndedEvent.RemainingHandsEntryR\x0eremainingHands\x1aA\n" +
+	"\x0fremaining_hands\x18\x03 \x03(\v2..tienlen.v1.GameEndedEvent.RemainingHandsEntryR\x0eremainingHands\x12;\n" +
+	"\tpenalties\x18\x04 \x03(\v2\x1d.tienlen.v1.SettlementPenaltyR\tpenalties\x12!\n" +
+	"\ffrozen_seats\x18\x05 \x03(\x05R\vfrozenSeats\x12'\n" +
+	"\x0fseed_commitment\x18\x06 \x01(\tR\x0eseedCommitment\x12\x1f\n" +
+	"\vserver_seed\x18\a \x01(\tR\n" +
+	"serverSeed\x12\x1f\n" +
+	"\vclient_salt\x18\b \x01(\tR\n" +
+	"clientSalt\x1aA\n" +
 	"\x13BalanceChangesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aW\n" +
 	"\x13RemainingHandsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\x05R\x03key\x12*\n" +
-	"\x05value\x18\x02 \x01(\v2\x14.tienlen.v1.CardListR\x05value:\x028\x01\"=\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.tienlen.v1.CardListR\x05value:\x028\x01\"\xa9\x01\n" +
+	"\x11SettlementPenalty\x12\x1d\n" +
+	"\n" +
+	"payer_seat\x18\x01 \x01(\x05R\tpayerSeat\x12\x1d\n" +
+	"\n" +
+	"payee_seat\x18\x02 \x01(\x05R\tpayeeSeat\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12&\n" +
+	"\x05cards\x18\x04 \x03(\v2\x10.tienlen.v1.CardR\x05cards\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\"U\n" +
 	"\x13PlayerFinishedEvent\x12\x12\n" +
 	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x12\n" +
-	"\x04rank\x18\x02 \x01(\x05R\x04rank\">\n" +
+	"\x04rank\x18\x02 \x01(\x05R\x04rank\x12\x16\n" +
+	"\x06frozen\x18\x03 \x01(\bR\x06frozen\"p\n" +
 	"\x0eGameErrorEvent\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xfd\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\bapp_code\x18\x03 \x01(\x0e2\x15.tienlen.v1.ErrorCodeR\aappCode\"\xa9\x03\n" +
 	"\x0fPigChoppedEvent\x12\x1f\n" +
 	"\vsource_seat\x18\x01 \x01(\x05R\n" +
 	"sourceSeat\x12\x1f\n" +
@@ -1761,14 +2685,32 @@ const file_tienlen_proto_rawDesc = "" +
 	"\tchop_type\x18\x03 \x01(\tR\bchopType\x125\n" +
 	"\rcards_chopped\x18\x04 \x03(\v2\x10.tienlen.v1.CardR\fcardsChopped\x127\n" +
 	"\x0ecards_chopping\x18\x05 \x03(\v2\x10.tienlen.v1.CardR\rcardsChopping\x12X\n" +
-	"\x0fbalance_changes\x18\x06 \x03(\v2/.tienlen.v1.PigChoppedEvent.BalanceChangesEntryR\x0ebalanceChanges\x1aA\n" +
+	"\x0fbalance_changes\x18\x06 \x03(\v2/.tienlen.v1.PigChoppedEvent.BalanceChangesEntryR\x0ebalanceChanges\x12*\n" +
+	"\x05chain\x18\a \x03(\v2\x14.tienlen.v1.ChopLinkR\x05chain\x1aA\n" +
 	"\x13BalanceChangesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"J\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"{\n" +
+	"\bChopLink\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12&\n" +
+	"\x05cards\x18\x02 \x03(\v2\x10.tienlen.v1.CardR\x05cards\x12\x1b\n" +
+	"\tchop_type\x18\x03 \x01(\tR\bchopType\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\"h\n" +
+	"\x0fInstantWinEvent\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x19\n" +
+	"\bwin_type\x18\x02 \x01(\tR\awinType\x12&\n" +
+	"\x05cards\x18\x03 \x03(\v2\x10.tienlen.v1.CardR\x05cards\"J\n" +
 	"\x0fInGameChatEvent\x12\x1d\n" +
 	"\n" +
 	"seat_index\x18\x01 \x01(\x05R\tseatIndex\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage*K\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9d\x01\n" +
+	"\tHintEvent\x12<\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1a.tienlen.v1.HintSuggestionR\vsuggestions\x12'\n" +
+	"\x0fhints_remaining\x18\x02 \x01(\x05R\x0ehintsRemaining\x12)\n" +
+	"\x10cooldown_seconds\x18\x03 \x01(\x03R\x0fcooldownSeconds\"f\n" +
+	"\x0eHintSuggestion\x12\x12\n" +
+	"\x04pass\x18\x01 \x01(\bR\x04pass\x12&\n" +
+	"\x05cards\x18\x02 \x03(\v2\x10.tienlen.v1.CardR\x05cards\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons*K\n" +
 	"\x04Suit\x12\x0f\n" +
 	"\vSUIT_SPADES\x10\x00\x12\x0e\n" +
 	"\n" +
@@ -1802,13 +2744,16 @@ const file_tienlen_proto_rawDesc = "" +
 	"\x16MATCH_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11MATCH_TYPE_CASUAL\x10\x01\x12\x12\n" +
 	"\x0eMATCH_TYPE_VIP\x10\x02\x12\x15\n" +
-	"\x11MATCH_TYPE_RANKED\x10\x03*\x86\x03\n" +
+	"\x11MATCH_TYPE_RANKED\x10\x03*\xb1\x04\n" +
 	"\x06OpCode\x12\x17\n" +
 	"\x13OP_CODE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12OP_CODE_START_GAME\x10\x01\x12\x16\n" +
 	"\x12OP_CODE_PLAY_CARDS\x10\x02\x12\x15\n" +
 	"\x11OP_CODE_PASS_TURN\x10\x03\x12\x1c\n" +
-	"\x18OP_CODE_REQUEST_NEW_GAME\x10\x04\x12\x19\n" +
+	"\x18OP_CODE_REQUEST_NEW_GAME\x10\x04\x12\x18\n" +
+	"\x14OP_CODE_REQUEST_HINT\x10\x05\x12\x1a\n" +
+	"\x16OP_CODE_SET_SPECTATING\x10\x06\x12\x15\n" +
+	"\x11OP_CODE_SET_READY\x10\a\x12\x19\n" +
 	"\x15OP_CODE_PLAYER_JOINED\x102\x12\x17\n" +
 	"\x13OP_CODE_PLAYER_LEFT\x103\x12\x18\n" +
 	"\x14OP_CODE_GAME_STARTED\x10d\x12\x17\n" +
@@ -1818,7 +2763,11 @@ const file_tienlen_proto_rawDesc = "" +
 	"\x12OP_CODE_GAME_ERROR\x10i\x12\x17\n" +
 	"\x13OP_CODE_PIG_CHOPPED\x10j\x12\x1b\n" +
 	"\x17OP_CODE_PLAYER_FINISHED\x10k\x12\x18\n" +
-	"\x14OP_CODE_IN_GAME_CHAT\x10l*\xf8\x01\n" +
+	"\x14OP_CODE_IN_GAME_CHAT\x10l\x12\x17\n" +
+	"\x13OP_CODE_INSTANT_WIN\x10m\x12\x10\n" +
+	"\fOP_CODE_HINT\x10n\x12\x17\n" +
+	"\x13OP_CODE_GAME_RESYNC\x10o\x12\x18\n" +
+	"\x14OP_CODE_REMATCH_VOTE\x10p*\xf8\x01\n" +
 	"\rErrorCategory\x12\x1e\n" +
 	"\x1aERROR_CATEGORY_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13ERROR_CATEGORY_AUTH\x10\x01\x12\x19\n" +
@@ -1827,10 +2776,16 @@ const file_tienlen_proto_rawDesc = "" +
 	"\x18ERROR_CATEGORY_NOT_FOUND\x10\x04\x12\x1b\n" +
 	"\x17ERROR_CATEGORY_CONFLICT\x10\x05\x12\x1c\n" +
 	"\x18ERROR_CATEGORY_TRANSIENT\x10\x06\x12\x1b\n" +
-	"\x17ERROR_CATEGORY_INTERNAL\x10\a*K\n" +
+	"\x17ERROR_CATEGORY_INTERNAL\x10\a*\x92\x02\n" +
 	"\tErrorCode\x12\x1a\n" +
 	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\"\n" +
-	"\x1dERROR_CODE_MATCH_VIP_REQUIRED\x10\xe9\aB\x12Z\x10tienlen/proto;pbb\x06proto3"
+	"\x1dERROR_CODE_MATCH_VIP_REQUIRED\x10\xe9\a\x12\x1e\n" +
+	"\x19ERROR_CODE_ROOM_NOT_FOUND\x10\xea\a\x12%\n" +
+	" ERROR_CODE_ROOM_SETTINGS_INVALID\x10\xeb\a\x12\x1c\n" +
+	"\x17ERROR_CODE_TIER_INVALID\x10\xec\a\x12\x1f\n" +
+	"\x1aERROR_CODE_BALANCE_TOO_LOW\x10\xed\a\x12 \n" +
+	"\x1bERROR_CODE_BALANCE_TOO_HIGH\x10\xee\a\x12\x1d\n" +
+	"\x18ERROR_CODE_RULES_INVALID\x10\xef\aB\x12Z\x10tienlen/proto;pbb\x06proto3"
 
 var (
 	file_tienlen_proto_rawDescOnce sync.Once
@@ -1845,7 +2800,7 @@ func file_tienlen_proto_rawDescGZIP() []byte {
 }
 
 var file_tienlen_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_tienlen_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_tienlen_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_tienlen_proto_goTypes = []any{
 	(Suit)(0),                     // 0: tienlen.v1.Suit
 	(Rank)(0),                     // 1: tienlen.v1.Rank
@@ -1863,22 +2818,32 @@ var file_tienlen_proto_goTypes = []any{
 	(*PlayCardsRequest)(nil),      // 13: tienlen.v1.PlayCardsRequest
 	(*PassTurnRequest)(nil),       // 14: tienlen.v1.PassTurnRequest
 	(*RequestNewGameRequest)(nil), // 15: tienlen.v1.RequestNewGameRequest
-	(*InGameChatRequest)(nil),     // 16: tienlen.v1.InGameChatRequest
-	(*PlayerJoinedEvent)(nil),     // 17: tienlen.v1.PlayerJoinedEvent
-	(*PlayerLeftEvent)(nil),       // 18: tienlen.v1.PlayerLeftEvent
-	(*MatchStateSnapshot)(nil),    // 19: tienlen.v1.MatchStateSnapshot
-	(*GameStartedEvent)(nil),      // 20: tienlen.v1.GameStartedEvent
-	(*CardPlayedEvent)(nil),       // 21: tienlen.v1.CardPlayedEvent
-	(*TurnPassedEvent)(nil),       // 22: tienlen.v1.TurnPassedEvent
-	(*CardList)(nil),              // 23: tienlen.v1.CardList
-	(*GameEndedEvent)(nil),        // 24: tienlen.v1.GameEndedEvent
-	(*PlayerFinishedEvent)(nil),   // 25: tienlen.v1.PlayerFinishedEvent
-	(*GameErrorEvent)(nil),        // 26: tienlen.v1.GameErrorEvent
-	(*PigChoppedEvent)(nil),       // 27: tienlen.v1.PigChoppedEvent
-	(*InGameChatEvent)(nil),       // 28: tienlen.v1.InGameChatEvent
-	nil,                           // 29: tienlen.v1.GameEndedEvent.BalanceChangesEntry
-	nil,                           // 30: tienlen.v1.GameEndedEvent.RemainingHandsEntry
-	nil,                           // 31: tienlen.v1.PigChoppedEvent.BalanceChangesEntry
+	(*RequestHintRequest)(nil),    // 16: tienlen.v1.RequestHintRequest
+	(*SetSpectatingRequest)(nil),  // 17: tienlen.v1.SetSpectatingRequest
+	(*SetReadyRequest)(nil),       // 18: tienlen.v1.SetReadyRequest
+	(*InGameChatRequest)(nil),     // 19: tienlen.v1.InGameChatRequest
+	(*PlayerJoinedEvent)(nil),     // 20: tienlen.v1.PlayerJoinedEvent
+	(*PlayerLeftEvent)(nil),       // 21: tienlen.v1.PlayerLeftEvent
+	(*MatchStateSnapshot)(nil),    // 22: tienlen.v1.MatchStateSnapshot
+	(*GameStartedEvent)(nil),      // 23: tienlen.v1.GameStartedEvent
+	(*GameResyncEvent)(nil),       // 24: tienlen.v1.GameResyncEvent
+	(*RematchVoteEvent)(nil),      // 25: tienlen.v1.RematchVoteEvent
+	(*CardPlayedEvent)(nil),       // 26: tienlen.v1.CardPlayedEvent
+	(*TurnPassedEvent)(nil),       // 27: tienlen.v1.TurnPassedEvent
+	(*CardList)(nil),              // 28: tienlen.v1.CardList
+	(*GameEndedEvent)(nil),        // 29: tienlen.v1.GameEndedEvent
+	(*SettlementPenalty)(nil),     // 30: tienlen.v1.SettlementPenalty
+	(*PlayerFinishedEvent)(nil),   // 31: tienlen.v1.PlayerFinishedEvent
+	(*GameErrorEvent)(nil),        // 32: tienlen.v1.GameErrorEvent
+	(*PigChoppedEvent)(nil),       // 33: tienlen.v1.PigChoppedEvent
+	(*ChopLink)(nil),              // 34: tienlen.v1.ChopLink
+	(*InstantWinEvent)(nil),       // 35: tienlen.v1.InstantWinEvent
+	(*InGameChatEvent)(nil),       // 36: tienlen.v1.InGameChatEvent
+	(*HintEvent)(nil),             // 37: tienlen.v1.HintEvent
+	(*HintSuggestion)(nil),        // 38: tienlen.v1.HintSuggestion
+	nil,                           // 39: tienlen.v1.GameEndedEvent.BalanceChangesEntry
+	nil,                           // 40: tienlen.v1.GameEndedEvent.RemainingHandsEntry
+	nil,                           // 41: tienlen.v1.PigChoppedEvent.BalanceChangesEntry
 }
 var file_tienlen_proto_depIdxs = []int32{
 	0,  // 0: tienlen.v1.Card.suit:type_name -> tienlen.v1.Suit
@@ -1888,19 +2853,30 @@ var file_tienlen_proto_depIdxs = []int32{
 	9,  // 4: tienlen.v1.MatchStateSnapshot.players:type_name -> tienlen.v1.PlayerState
 	2,  // 5: tienlen.v1.GameStartedEvent.phase:type_name -> tienlen.v1.GamePhase
 	8,  // 6: tienlen.v1.GameStartedEvent.hand:type_name -> tienlen.v1.Card
-	8,  // 7: tienlen.v1.CardPlayedEvent.cards:type_name -> tienlen.v1.Card
-	8,  // 8: tienlen.v1.CardList.cards:type_name -> tienlen.v1.Card
-	29, // 9: tienlen.v1.GameEndedEvent.balance_changes:type_name -> tienlen.v1.GameEndedEvent.BalanceChangesEntry
-	30, // 10: tienlen.v1.GameEndedEvent.remaining_hands:type_name -> tienlen.v1.GameEndedEvent.RemainingHandsEntry
-	8,  // 11: tienlen.v1.PigChoppedEvent.cards_chopped:type_name -> tienlen.v1.Card
-	8,  // 12: tienlen.v1.PigChoppedEvent.cards_chopping:type_name -> tienlen.v1.Card
-	31, // 13: tienlen.v1.PigChoppedEvent.balance_changes:type_name -> tienlen.v1.PigChoppedEvent.BalanceChangesEntry
-	23, // 14: tienlen.v1.GameEndedEvent.RemainingHandsEntry.value:type_name -> tienlen.v1.CardList
-	15, // [15:15] is the sub-list for method output_type
-	15, // [15:15] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	8,  // 7: tienlen.v1.GameResyncEvent.hand:type_name -> tienlen.v1.Card
+	8,  // 8: tienlen.v1.GameResyncEvent.last_played_cards:type_name -> tienlen.v1.Card
+	8,  // 9: tienlen.v1.GameResyncEvent.discards:type_name -> tienlen.v1.Card
+	8,  // 10: tienlen.v1.CardPlayedEvent.cards:type_name -> tienlen.v1.Card
+	8,  // 11: tienlen.v1.CardList.cards:type_name -> tienlen.v1.Card
+	39, // 12: tienlen.v1.GameEndedEvent.balance_changes:type_name -> tienlen.v1.GameEndedEvent.BalanceChangesEntry
+	40, // 13: tienlen.v1.GameEndedEvent.remaining_hands:type_name -> tienlen.v1.GameEndedEvent.RemainingHandsEntry
+	30, // 14: tienlen.v1.GameEndedEvent.penalties:type_name -> tienlen.v1.SettlementPenalty
+	8,  // 15: tienlen.v1.SettlementPenalty.cards:type_name -> tienlen.v1.Card
+	6,  // 16: tienlen.v1.GameErrorEvent.app_code:type_name -> tienlen.v1.ErrorCode
+	8,  // 17: tienlen.v1.PigChoppedEvent.cards_chopped:type_name -> tienlen.v1.Card
+	8,  // 18: tienlen.v1.PigChoppedEvent.cards_chopping:type_name -> tienlen.v1.Card
+	41, // 19: tienlen.v1.PigChoppedEvent.balance_changes:type_name -> tienlen.v1.PigChoppedEvent.BalanceChangesEntry
+	34, // 20: tienlen.v1.PigChoppedEvent.chain:type_name -> tienlen.v1.ChopLink
+	8,  // 21: tienlen.v1.ChopLink.cards:type_name -> tienlen.v1.Card
+	8,  // 22: tienlen.v1.InstantWinEvent.cards:type_name -> tienlen.v1.Card
+	38, // 23: tienlen.v1.HintEvent.suggestions:type_name -> tienlen.v1.HintSuggestion
+	8,  // 24: tienlen.v1.HintSuggestion.cards:type_name -> tienlen.v1.Card
+	28, // 25: tienlen.v1.GameEndedEvent.RemainingHandsEntry.value:type_name -> tienlen.v1.CardList
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_tienlen_proto_init() }
@@ -1914,7 +2890,7 @@ func file_tienlen_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tienlen_proto_rawDesc), len(file_tienlen_proto_rawDesc)),
 			NumEnums:      7,
-			NumMessages:   25,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   0,
 		},